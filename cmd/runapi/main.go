@@ -6,8 +6,12 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
 	"github.com/cheivin/go-runapi/pkg/generator"
 	"github.com/cheivin/go-runapi/pkg/showdoc"
 	"github.com/cheivin/go-runapi/pkg/types"
@@ -20,20 +24,27 @@ const (
 	ModeGenerate     Mode = "generate" // 仅生成文档
 	ModePush         Mode = "push"     // 仅推送文档
 	ModeGeneratePush Mode = "genpush"  // 生成并推送变更文档
+	ModeSunset       Mode = "sunset"   // 列出已到达计划下线日期的接口
 )
 
 func main() {
 	var (
-		configFile string
-		mode       string
-		help       bool
-		initConfig bool
+		configFile      string
+		mode            string
+		help            bool
+		initConfig      bool
+		strict          bool
+		diagnostics     string
+		diagnosticsFile string
 	)
 
 	flag.StringVar(&configFile, "config", "", "指定配置文件路径")
-	flag.StringVar(&mode, "mode", "generate", "运行模式: generate(仅生成), push(仅推送), genpush(生成并推送)")
+	flag.StringVar(&mode, "mode", "generate", "运行模式: generate(仅生成), push(仅推送), genpush(生成并推送), sunset(下线报告)")
 	flag.BoolVar(&help, "help", false, "显示帮助信息")
 	flag.BoolVar(&initConfig, "init", false, "初始化配置文件")
+	flag.BoolVar(&strict, "strict", false, "严格模式，存在诊断问题时执行失败")
+	flag.StringVar(&diagnostics, "diagnostics", diagnostic.FormatText, "诊断信息输出格式: text, json, github")
+	flag.StringVar(&diagnosticsFile, "diagnostics-file", "", "诊断信息输出文件，默认输出到标准错误")
 	flag.Parse()
 
 	if help {
@@ -48,12 +59,19 @@ func main() {
 
 	// 验证运行模式
 	runMode := Mode(mode)
-	if runMode != ModeGenerate && runMode != ModePush && runMode != ModeGeneratePush {
+	if runMode != ModeGenerate && runMode != ModePush && runMode != ModeGeneratePush && runMode != ModeSunset {
 		fmt.Printf("错误: 无效的运行模式 '%s'\n", mode)
 		showHelp()
 		os.Exit(1)
 	}
 
+	// 验证诊断信息输出格式
+	if !diagnostic.ValidFormat(diagnostics) {
+		fmt.Printf("错误: 无效的诊断信息输出格式 '%s'\n", diagnostics)
+		showHelp()
+		os.Exit(1)
+	}
+
 	// 获取当前目录
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -65,6 +83,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("加载配置失败: %v", err)
 	}
+	if strict {
+		cfg.Scan.Strict = true
+	}
 
 	// 验证根扫描目录
 	if _, err := os.Stat(cfg.Scan.Dir); os.IsNotExist(err) {
@@ -82,6 +103,14 @@ func main() {
 	if len(cfg.Scan.ExtraDirs) > 0 {
 		fmt.Printf("额外扫描目录: %v\n", cfg.Scan.ExtraDirs)
 	}
+	fmt.Printf("结构体解析模式: %s\n", cfg.Scan.Resolver)
+	fmt.Printf("注释格式: %s\n", cfg.Scan.Dialect)
+	if cfg.Scan.Routes {
+		fmt.Println("路由识别: 已启用")
+	}
+	if cfg.Scan.Strict {
+		fmt.Println("严格模式: 已启用")
+	}
 	fmt.Printf("输出文件: %s\n", cfg.Output.File)
 	fmt.Printf("运行模式: %s\n", mode)
 
@@ -95,8 +124,13 @@ func main() {
 		err = runPushMode(gen, cfg)
 	case ModeGeneratePush:
 		err = runGeneratePushMode(gen, cfg)
+	case ModeSunset:
+		err = runSunsetMode(gen, cfg)
 	}
 
+	if writeErr := writeDiagnostics(diagnostic.RelativeTo(gen.Diagnostics(), currentDir), diagnostics, diagnosticsFile); writeErr != nil {
+		log.Fatalf("输出诊断信息失败: %v", writeErr)
+	}
 	if err != nil {
 		log.Fatalf("执行失败: %v", err)
 	}
@@ -104,6 +138,32 @@ func main() {
 	fmt.Println("执行完成")
 }
 
+// writeDiagnostics 输出诊断信息，指定文件时总是写入文件，便于CI读取
+func writeDiagnostics(diags []diagnostic.Diagnostic, format, file string) error {
+	if file == "" {
+		if len(diags) == 0 {
+			return nil
+		}
+		if format == diagnostic.FormatText {
+			fmt.Fprintf(os.Stderr, "发现 %d 个错误, %d 个警告:\n",
+				diagnostic.Count(diags, diagnostic.SeverityError), diagnostic.Count(diags, diagnostic.SeverityWarning))
+		}
+		return diagnostic.Write(os.Stderr, diags, format)
+	}
+
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return diagnostic.Write(f, diags, format)
+}
+
 // runGenerateMode 仅生成文档模式
 func runGenerateMode(gen *generator.Generator) error {
 	fmt.Println("\n=== 生成文档模式 ===")
@@ -198,6 +258,48 @@ func runGeneratePushMode(gen *generator.Generator, cfg *config.Config) error {
 	return nil
 }
 
+// runSunsetMode 下线报告模式，列出已到达计划下线日期（@sunset）的接口，严格模式下存在这样的接口时执行失败
+func runSunsetMode(gen *generator.Generator, cfg *config.Config) error {
+	fmt.Println("\n=== 下线报告模式 ===")
+
+	docs, _, err := gen.GetGeneratedDocuments()
+	if err != nil {
+		return err
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	var reached []types.APIDoc
+	for _, doc := range docs {
+		if doc.SunsetReached(today) {
+			reached = append(reached, doc)
+		}
+	}
+	if len(reached) == 0 {
+		fmt.Printf("没有已到达计划下线日期的接口 (%s)\n", today)
+		return nil
+	}
+
+	sort.SliceStable(reached, func(i, j int) bool {
+		return reached[i].Sunset < reached[j].Sunset
+	})
+	fmt.Printf("%d 个接口已到达计划下线日期 (%s):\n", len(reached), today)
+	for _, doc := range reached {
+		router := doc.URL
+		if router == "" {
+			router = doc.Router
+		}
+		fmt.Printf("  %s  %s %s  %s (%s)\n", doc.Sunset, strings.ToUpper(doc.Method), router, doc.Title, doc.FunctionName)
+		if doc.Deprecated {
+			fmt.Printf("              %s\n", types.DeprecatedRemark("", doc.DeprecatedNote))
+		}
+	}
+
+	if cfg.Scan.Strict {
+		return fmt.Errorf("严格模式下存在 %d 个已到达计划下线日期的接口", len(reached))
+	}
+	return nil
+}
+
 // initConfigFile 初始化配置文件
 func initConfigFile() {
 	currentDir, err := os.Getwd()
@@ -233,13 +335,24 @@ func showHelp() {
 	fmt.Println("                  generate  - 仅生成文档")
 	fmt.Println("                  push      - 仅推送文档到ShowDoc")
 	fmt.Println("                  genpush   - 生成并推送变更文档")
+	fmt.Println("                  sunset    - 列出已到达计划下线日期的接口")
 	fmt.Println("  -init           初始化配置文件")
+	fmt.Println("  -strict         严格模式，存在错误或警告时执行失败")
+	fmt.Println("  -diagnostics string")
+	fmt.Println("                  诊断信息输出格式 (默认: text)")
+	fmt.Println("                  text      - 文本，包含 文件:行:列 位置和修复建议")
+	fmt.Println("                  json      - JSON数组")
+	fmt.Println("                  github    - GitHub Actions 注解")
+	fmt.Println("  -diagnostics-file string")
+	fmt.Println("                  诊断信息输出文件 (默认输出到标准错误)")
 	fmt.Println("  -help           显示帮助信息")
 	fmt.Println()
 	fmt.Println("配置文件说明:")
 	fmt.Println("  scan.dir        - 根扫描路径（用于结构体解析等）")
 	fmt.Println("  scan.scan       - 带文档注释的文件扫描路径（可选，默认同dir）")
 	fmt.Println("  scan.extra_dirs - 额外的扫描目录")
+	fmt.Println("  scan.resolver   - 结构体解析模式: ast(默认), packages(基于go/packages类型检查)")
+	fmt.Println("  scan.strict     - 严格模式，存在错误或警告时执行失败")
 	fmt.Println()
 	fmt.Println("配置文件查找顺序:")
 	fmt.Println("  1. 当前运行目录的 runapi.json")
@@ -249,6 +362,8 @@ func showHelp() {
 	fmt.Println("  runapi                           # 使用默认配置生成文档")
 	fmt.Println("  runapi -mode push                # 仅推送现有文档到ShowDoc")
 	fmt.Println("  runapi -mode genpush             # 生成并推送变更文档")
+	fmt.Println("  runapi -mode sunset              # 列出已到达计划下线日期的接口")
 	fmt.Println("  runapi -config ./custom.json     # 使用指定配置文件")
 	fmt.Println("  runapi -init                     # 初始化配置文件")
+	fmt.Println("  runapi -strict -diagnostics github  # 在CI中校验文档注释")
 }
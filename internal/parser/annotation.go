@@ -0,0 +1,221 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// annotationCollector 收集文件中的文档注释
+// 除函数声明外，注释还可以写在变量声明、赋值语句、返回语句、路由注册语句和路由表元素上
+type annotationCollector struct {
+	p        *Parser
+	filePath string
+	comments ast.CommentMap
+	seen     map[*ast.CommentGroup]bool
+	closures map[*ast.FuncLit]string // 函数字面量按 Go 的规则得到的名称，如 Setup.func2
+	apiDocs  []types.APIDoc
+}
+
+// newAnnotationCollector 创建文档注释收集器
+func newAnnotationCollector(p *Parser, filePath string, comments ast.CommentMap) *annotationCollector {
+	return &annotationCollector{
+		p:        p,
+		filePath: filePath,
+		comments: comments,
+		seen:     make(map[*ast.CommentGroup]bool),
+		closures: make(map[*ast.FuncLit]string),
+	}
+}
+
+// add 解析文档注释，name 为处理函数名，为空时使用所在的函数名
+// table 不为空时使用路由表元素中的请求方法和路径补全接口
+func (c *annotationCollector) add(doc *ast.CommentGroup, name, enclosing string, table *ast.CompositeLit) {
+	if doc == nil || c.seen[doc] {
+		return
+	}
+	c.seen[doc] = true
+
+	if name == "" {
+		name = enclosing
+	}
+	apiDoc, ok := c.p.parseAnnotation(doc, c.filePath, name)
+	if !ok {
+		return
+	}
+
+	if table != nil {
+		applyRouteTable(apiDoc, table)
+	}
+
+	// 添加位置信息用于错误定位
+	apiDoc.FilePath = c.filePath
+	apiDoc.FunctionName = name
+	apiDoc.Line = c.p.fset.Position(doc.Pos()).Line
+
+	c.apiDocs = append(c.apiDocs, *apiDoc)
+}
+
+// addComments 解析与节点关联的注释
+func (c *annotationCollector) addComments(node ast.Node, name, enclosing string, table *ast.CompositeLit) {
+	for _, doc := range c.comments[node] {
+		c.add(doc, name, enclosing, table)
+	}
+}
+
+// nameClosures 按源码顺序为函数中的每个函数字面量命名，与 Go 的规则一致
+// 函数中的为 所在函数.func1、所在函数.func2，嵌套的为 所在函数.func1.func1，无论是否写了文档注释都参与计数
+func (c *annotationCollector) nameClosures(node ast.Node, enclosing string) {
+	count := 0
+	ast.Inspect(node, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		if _, named := c.closures[lit]; named {
+			return false
+		}
+		count++
+		name := fmt.Sprintf("%s.func%d", enclosing, count)
+		c.closures[lit] = name
+		c.nameClosures(lit.Body, name)
+		return false
+	})
+}
+
+// handlerName 返回处理函数表达式的函数名，函数字面量使用 nameClosures 得到的名称
+func (c *annotationCollector) handlerName(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.FuncLit); ok {
+		return c.closures[lit]
+	}
+	return handlerName(expr)
+}
+
+// genDecl 解析变量声明上的文档注释，如 var ListUsers = func(w http.ResponseWriter, r *http.Request) {...}
+// 声明中只有一个变量时注释可以写在 var 关键字上
+func (c *annotationCollector) genDecl(decl *ast.GenDecl) {
+	if decl.Tok != token.VAR {
+		return
+	}
+	for _, spec := range decl.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		doc := valueSpec.Doc
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+
+		name := valueSpec.Names[0].Name
+		c.add(doc, name, name, nil)
+		for _, value := range valueSpec.Values {
+			c.nameClosures(value, name)
+			c.walk(value, name)
+		}
+	}
+}
+
+// walk 解析函数体或变量值中的文档注释，enclosing 为所在的函数或变量名
+func (c *annotationCollector) walk(node ast.Node, enclosing string) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			c.genDecl(n)
+			return false
+		case *ast.AssignStmt:
+			// listUsers := func(...) {...}，使用变量名
+			name := ""
+			if len(n.Lhs) == 1 {
+				name = handlerName(n.Lhs[0])
+			}
+			if name == "" && len(n.Rhs) == 1 {
+				name = c.handlerName(n.Rhs[0])
+			}
+			c.addComments(n, name, enclosing, nil)
+		case *ast.ReturnStmt:
+			// 处理函数工厂返回的函数字面量使用工厂函数名，如 func ListUsers(svc Service) http.HandlerFunc
+			c.addComments(n, enclosing, enclosing, nil)
+		case *ast.ExprStmt:
+			// r.GET("/users", ListUsers) 使用最后一个参数的函数名，函数字面量为匿名函数
+			name := ""
+			if call, ok := n.X.(*ast.CallExpr); ok && len(call.Args) > 0 {
+				name = c.handlerName(call.Args[len(call.Args)-1])
+			}
+			c.addComments(n, name, enclosing, nil)
+		case *ast.CompositeLit:
+			// 注释写在元素的 { 之前时与元素关联，写在元素内部时与第一个字段关联
+			handler := c.routeTableHandler(n)
+			c.addComments(n, handler, enclosing, n)
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					c.addComments(kv, handler, enclosing, n)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// handlerName 返回处理函数表达式的函数名，如 ListUsers、h.List、user.NewList(svc)，函数字面量返回空
+func handlerName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.CallExpr:
+		return handlerName(e.Fun)
+	case *ast.ParenExpr:
+		return handlerName(e.X)
+	}
+	return ""
+}
+
+// routeTableHandler 返回路由表元素中 Handler 等字段的函数名
+func (c *annotationCollector) routeTableHandler(table *ast.CompositeLit) string {
+	for _, elt := range table.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && strings.Contains(strings.ToLower(key.Name), "handler") {
+			return c.handlerName(kv.Value)
+		}
+	}
+	return ""
+}
+
+// applyRouteTable 使用路由表元素中的请求方法和路径字段补全接口，如 {Method: "GET", Path: "/users", Handler: ListUsers}
+func applyRouteTable(apiDoc *types.APIDoc, table *ast.CompositeLit) {
+	for _, elt := range table.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		lit, ok := kv.Value.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			continue
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			continue
+		}
+
+		switch strings.ToLower(key.Name) {
+		case "method":
+			if apiDoc.Method == "" {
+				apiDoc.Method = strings.ToLower(value)
+			}
+		case "path", "pattern", "route", "router", "url":
+			if apiDoc.Router == "" && apiDoc.URL == "" {
+				apiDoc.Router = value
+			}
+		}
+	}
+}
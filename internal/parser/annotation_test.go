@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+)
+
+func TestMultilineAnnotations(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "multiline")
+	if len(diagnostics) > 0 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+
+	tests := []struct {
+		title       string
+		description string
+		remark      string
+	}{
+		{
+			title:       "用户登录",
+			description: "用户登录的接口，\n支持账号密码和短信验证码两种方式",
+			remark:      "## 错误码\n\n| 错误码 | 说明 |\n|--------|------|\n| 1001   | 密码错误 |",
+		},
+		{
+			// runapi 之后的自由文本追加到描述，重复的标签追加为新行，代码块中的 @ 行属于备注
+			title:       "退出登录",
+			description: "退出后令牌失效\n退出当前账号",
+			remark:      "第一条备注\n第二条备注\n示例：\n```\n@title 不是标签\n```",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			doc := findDoc(t, docs, tt.title)
+			if doc.Description != tt.description {
+				t.Errorf("description = %q, want %q", doc.Description, tt.description)
+			}
+			if doc.Remark != tt.remark {
+				t.Errorf("remark = %q, want %q", doc.Remark, tt.remark)
+			}
+		})
+	}
+}
+
+func TestHandlerAnnotations(t *testing.T) {
+	// 函数名用于错误定位、operationId 和路由识别，匿名函数按 Go 的规则命名
+	tests := []struct {
+		title    string
+		function string
+		method   string
+		path     string
+	}{
+		{"方法", "Show", "get", "/users/show"},
+		{"处理函数工厂", "ListUsers", "", ""},
+		{"变量声明", "GetUser", "get", "/users/{id}"},
+		{"分组变量声明", "ExportUsers", "get", "/users/export"},
+		{"路由表", "CreateUser", "post", "/users"},
+		{"赋值语句", "update", "put", "/users/update"},
+		// 没有文档注释的函数字面量同样参与计数，赋值给 update 的为 Setup.func1
+		{"路由注册语句", "Setup.func2", "delete", "/users/delete"},
+		{"嵌套的匿名函数", "Setup.func3.func1", "get", "/admin/users"},
+	}
+	for _, routes := range []bool{false, true} {
+		t.Run(fmt.Sprintf("routes=%t", routes), func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "handlers", func(cfg *config.Config) {
+				cfg.Scan.Routes = routes
+			})
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			if len(docs) != len(tests) {
+				t.Fatalf("got %d docs, want %d", len(docs), len(tests))
+			}
+			for _, tt := range tests {
+				// 处理函数工厂通过注册的路由 ListUsers(nil) 关联
+				if routes && tt.function == "ListUsers" {
+					tt.method, tt.path = "get", "/users"
+				}
+				doc := findDoc(t, docs, tt.title)
+				path := doc.URL
+				if path == "" {
+					path = doc.Router
+				}
+				if doc.FunctionName != tt.function || doc.Method != tt.method || path != tt.path {
+					t.Errorf("%s = {function: %s, method: %s, path: %s}, want %+v", tt.title, doc.FunctionName, doc.Method, path, tt)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// bindingTags 各参数位置读取字段名的标签，按顺序查找
+var bindingTags = map[string][]string{
+	"query":    {"form", "query"},
+	"formData": {"form"},
+	"header":   {"header"},
+	"path":     {"uri", "param"},
+}
+
+// parseBindingParams 解析 @query/@form/@header/@uri 注释，将结构体展开为对应位置的参数
+func (p *Parser) parseBindingParams(apiDoc *types.APIDoc, location, value, filePath string) {
+	structKey, err := p.resolveStructReference(value, filePath)
+	if err != nil {
+		// 如果解析失败，尝试直接查找
+		structKey = value
+	}
+
+	if _, exists := p.structInfos[structKey]; !exists {
+		p.warnStructNotFound(value)
+		return
+	}
+
+	params := p.bindingParams(structKey, location, make(map[string]bool))
+	switch location {
+	case "query":
+		apiDoc.Query = append(apiDoc.Query, params...)
+	case "formData":
+		apiDoc.FormData = append(apiDoc.FormData, params...)
+	case "header":
+		apiDoc.Header = append(apiDoc.Header, params...)
+	case "path":
+		apiDoc.Path = append(apiDoc.Path, params...)
+	}
+}
+
+// bindingParams 按参数位置对应的标签展开结构体字段
+// 与gin的表单绑定一致：没有标签时使用Go字段名，嵌入字段和未设置标签的结构体字段展开到同一层级
+func (p *Parser) bindingParams(structKey, location string, visiting map[string]bool) []types.RequestParam {
+	structInfo, exists := p.structInfos[structKey]
+	if !exists || visiting[structKey] {
+		return nil
+	}
+	visiting[structKey] = true
+	defer delete(visiting, structKey)
+
+	var params []types.RequestParam
+	for _, field := range structInfo.Fields {
+		name, tagged := bindingName(field, location)
+		if name == "-" {
+			continue
+		}
+
+		// 嵌入字段，或没有设置标签的结构体字段（映射为其他类型的结构体除外，如 time.Time）
+		_, known := p.knownType(field.Ref)
+		if field.Embedded || (!tagged && field.Ref != "" && !known && !strings.HasPrefix(field.Type, "[]")) {
+			if field.Ref != "" {
+				params = append(params, p.bindingParams(field.Ref, location, visiting)...)
+			}
+			continue
+		}
+
+		required, _ := hasRequiredRule(field.Tag)
+		// 路径参数始终必传
+		if location == "path" {
+			required = true
+		}
+
+		paramType := p.mapBindingType(field.Type)
+		constraints := field.Constraints
+		if mapping, ok := p.knownType(field.Ref); ok {
+			paramType = p.knownRequestType(mapping)
+			constraints = constraints.Merge(types.Constraints{Format: mapping.Format})
+		} else if mapping, ok := p.knownType(strings.TrimPrefix(field.Type, "*")); ok {
+			constraints = constraints.Merge(types.Constraints{Format: mapping.Format})
+		}
+		if expr, err := parseTypeExpr(strings.TrimPrefix(field.Type, "*")); err == nil {
+			constraints = constraints.Merge(arrayLengthConstraints(expr))
+		}
+		// 命名类型使用底层类型，常量作为可选值
+		if namedType, ok := p.fieldNamedType(structInfo.Package, structInfo.FilePath, field.Type); ok {
+			if paramType == "object" {
+				paramType = p.mapGoTypeToRequestType(namedType.Underlying)
+			}
+			constraints = constraints.Merge(namedType.EnumConstraints())
+		}
+
+		remark := field.Remark
+		if field.Deprecated {
+			remark = types.DeprecatedRemark(remark, field.DeprecatedNote)
+		}
+
+		params = append(params, types.RequestParam{
+			Name:        name,
+			Type:        paramType,
+			Require:     fmt.Sprintf("%t", required),
+			Remark:      remark,
+			Deprecated:  field.Deprecated,
+			Constraints: constraints,
+		})
+	}
+
+	return params
+}
+
+// bindingName 返回字段在指定参数位置的名称，tagged 表示是否通过标签指定
+func bindingName(field types.FieldInfo, location string) (string, bool) {
+	structTag := reflect.StructTag(field.Tag)
+	for _, key := range bindingTags[location] {
+		if value, ok := structTag.Lookup(key); ok {
+			name, _, _ := strings.Cut(value, ",")
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+	return field.GoName, false
+}
+
+// mapBindingType 将绑定字段的Go类型映射为请求参数类型，上传文件映射为 file
+func (p *Parser) mapBindingType(goType string) string {
+	if expr, err := parseTypeExpr(goType); err == nil && strings.HasSuffix(expr.element().Name, "multipart.FileHeader") {
+		// 多文件上传（[]*multipart.FileHeader）为数组
+		if strings.HasPrefix(strings.TrimPrefix(goType, "*"), "[]") {
+			return "array"
+		}
+		return "file"
+	}
+	return p.mapGoTypeToRequestType(goType)
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+func TestBindingParams(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "binding")
+	if len(diagnostics) > 0 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+	update := findDoc(t, docs, "更新条目")
+	upload := findDoc(t, docs, "上传文件")
+
+	tests := []struct {
+		name    string
+		params  []types.RequestParam
+		names   []string
+		types   []string
+		require []string
+	}{
+		{
+			name:    "query",
+			params:  update.Query,
+			names:   []string{"page", "size", "keyword", "status", "Sort"},
+			types:   []string{"int", "int", "string", "string", "string"},
+			require: []string{"false", "false", "true", "false", "false"},
+		},
+		{
+			name:    "header",
+			params:  update.Header,
+			names:   []string{"X-Token"},
+			types:   []string{"string"},
+			require: []string{"true"},
+		},
+		{
+			name:    "uri",
+			params:  update.Path,
+			names:   []string{"id"},
+			types:   []string{"long"},
+			require: []string{"true"},
+		},
+		{
+			name:    "form",
+			params:  upload.FormData,
+			names:   []string{"file", "name"},
+			types:   []string{"file", "string"},
+			require: []string{"true", "false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestNames(tt.params); !equalNames(got, tt.names) {
+				t.Fatalf("names = %v, want %v", got, tt.names)
+			}
+			for i, param := range tt.params {
+				if param.Type != tt.types[i] || param.Require != tt.require[i] {
+					t.Errorf("%s = {type: %s, require: %s}, want {%s %s}", param.Name, param.Type, param.Require, tt.types[i], tt.require[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBindingRequired(t *testing.T) {
+	docs, _ := parseTestdata(t, "binding")
+	doc := findDoc(t, docs, "更新条目")
+
+	// json:"-" 的字段不出现在请求体和响应中；binding/validate 的 required 规则只作用于请求体
+	tests := []struct {
+		path     string
+		request  bool
+		response bool
+	}{
+		{"name", true, true},
+		{"note", false, true},
+		{"remark", true, false},
+	}
+	for _, tt := range tests {
+		if node := schemaAt(t, doc.BodySchema, tt.path); node.Required != tt.request {
+			t.Errorf("request %s required = %t, want %t", tt.path, node.Required, tt.request)
+		}
+		if node := schemaAt(t, doc.ResponseSchema, tt.path); node.Required != tt.response {
+			t.Errorf("response %s required = %t, want %t", tt.path, node.Required, tt.response)
+		}
+	}
+	for _, schema := range []*types.Schema{doc.BodySchema, doc.ResponseSchema} {
+		if schema.Field("ID") != nil || schema.Field("id") != nil || len(schema.Children) != 3 {
+			t.Errorf("fields = %+v", schema.Children)
+		}
+	}
+}
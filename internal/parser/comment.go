@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+)
+
+// docLine 注释中的一行文本及其位置
+type docLine struct {
+	text string
+	pos  token.Pos
+}
+
+// docLines 将文档注释转换为文本行，支持 // 和 /* */ 两种注释
+// 行注释去掉 // 和其后的一个空格，块注释去掉每行开头的 * 装饰，均保留其余缩进
+func docLines(doc *ast.CommentGroup) []string {
+	var lines []string
+	for _, line := range commentLines(doc) {
+		lines = append(lines, line.text)
+	}
+	return lines
+}
+
+// commentLines 将文档注释转换为带位置的文本行，位置用于诊断信息定位
+func commentLines(doc *ast.CommentGroup) []docLine {
+	var lines []docLine
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, "/*") {
+			lines = append(lines, docLine{text: commentLine(comment.Text), pos: comment.Slash})
+			continue
+		}
+
+		body := strings.TrimSuffix(strings.TrimPrefix(comment.Text, "/*"), "*/")
+		blockLines := strings.Split(body, "\n")
+		decorated := isDecoratedBlock(blockLines)
+		offset := len("/*")
+		for _, line := range blockLines {
+			pos := comment.Slash + token.Pos(offset)
+			offset += len(line) + 1
+			if decorated {
+				if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "*") {
+					line = strings.TrimPrefix(trimmed, "*")
+				}
+			}
+			lines = append(lines, docLine{text: strings.TrimRight(strings.TrimPrefix(line, " "), " \t\r"), pos: pos})
+		}
+	}
+	return lines
+}
+
+// commentLine 去掉注释的 // 前缀和其后的一个空格，保留其余缩进
+func commentLine(comment string) string {
+	line := strings.TrimPrefix(comment, "//")
+	return strings.TrimRight(strings.TrimPrefix(line, " "), " \t\r")
+}
+
+// isDecoratedBlock 块注释除第一行外的非空行是否都以 * 开头，如
+//
+//	/*
+//	 * runapi
+//	 */
+func isDecoratedBlock(lines []string) bool {
+	decorated := false
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "*") {
+			return false
+		}
+		decorated = true
+	}
+	return decorated
+}
+
+// fieldDeprecation 解析字段注释中的废弃标记，返回去掉标记后的字段注释、是否已废弃和废弃说明
+// 支持Go惯用的 Deprecated: 段落（写在字段上方或行尾），以及行尾注释中的 @deprecated，如 // 昵称 @deprecated 使用 nickname
+func fieldDeprecation(field *ast.Field, remark string) (string, bool, string) {
+	if before, note, found := strings.Cut(remark, "@deprecated"); found {
+		return strings.TrimSpace(before), true, strings.TrimSpace(note)
+	}
+
+	for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, line := range docLines(group) {
+			if note, found := strings.CutPrefix(strings.TrimSpace(line), "Deprecated:"); found {
+				// 行尾注释只有废弃标记时不作为字段注释
+				if strings.HasPrefix(remark, "Deprecated:") {
+					remark = ""
+				}
+				return remark, true, strings.TrimSpace(note)
+			}
+		}
+	}
+	return remark, false, ""
+}
+
+// isMarker 检查一行文本是否为文档标记，忽略大小写，支持 runapi、@runapi 和 runapi: 等写法
+func (p *Parser) isMarker(text string) bool {
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "@"), ":")
+	return strings.EqualFold(strings.TrimSpace(text), p.marker)
+}
+
+// hasMarker 检查文档注释是否包含文档标记
+func (p *Parser) hasMarker(doc *ast.CommentGroup) bool {
+	for _, line := range docLines(doc) {
+		if p.isMarker(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMissingMarker 注释中包含 @catalog 标签但缺少文档标记时输出警告，避免标记写错导致接口被静默忽略
+// 只检查 runapi 特有的 @catalog，与 swag 等工具共存时不会误报
+func (p *Parser) checkMissingMarker(doc *ast.CommentGroup, name string) {
+	for _, line := range docLines(doc) {
+		if strings.HasPrefix(strings.TrimSpace(line), "@catalog") {
+			p.diag.Warnf(doc.Pos(), diagnostic.CodeMissingMarker, "函数 %s 的注释包含 @catalog 但缺少 %s 标记，已忽略", name, p.marker)
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+)
+
+func TestDocLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    []string
+	}{
+		{"line comments", "// runapi\n//@title 标题\n//   缩进", []string{"runapi", "@title 标题", "  缩进"}},
+		{"trailing spaces", "// runapi  \t", []string{"runapi"}},
+		{"decorated block", "/*\n * runapi\n * @title 标题\n */", []string{"", "runapi", "@title 标题", ""}},
+		{"plain block", "/* runapi\n@title 标题\n  * 列表 */", []string{"runapi", "@title 标题", " * 列表"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := goparser.ParseFile(token.NewFileSet(), "", "package p\n\n"+tt.comment+"\nfunc F() {}\n", goparser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := docLines(file.Decls[0].(*ast.FuncDecl).Doc); !equalNames(got, tt.want) {
+				t.Errorf("docLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMarker(t *testing.T) {
+	tests := []struct {
+		marker string
+		text   string
+		want   bool
+	}{
+		{"runapi", "runapi", true},
+		{"runapi", "RunAPI", true},
+		{"runapi", "@runapi", true},
+		{"runapi", "runapi:", true},
+		{"runapi", "runapi doc", false},
+		{"runapi", "apidoc", false},
+		{"apidoc", "@apidoc", true},
+		{"apidoc", "runapi", false},
+	}
+	for _, tt := range tests {
+		p := NewParser(&config.Config{Scan: config.ScanConfig{Marker: tt.marker}})
+		if got := p.isMarker(tt.text); got != tt.want {
+			t.Errorf("isMarker(%q) with marker %s = %t, want %t", tt.text, tt.marker, got, tt.want)
+		}
+	}
+}
+
+func TestMarkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		marker  string
+		titles  []string
+		missing []string
+	}{
+		{
+			name:    "default marker",
+			titles:  []string{"块注释", "无装饰块注释", "无空格", "大写和尾随空格", "at标记"},
+			missing: []string{"Custom", "Missing"},
+		},
+		{
+			name:    "custom marker",
+			marker:  "apidoc",
+			titles:  []string{"自定义标记"},
+			missing: []string{"Create", "Plain", "NoSpace", "Upper", "At", "Missing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "markers", func(cfg *config.Config) {
+				cfg.Scan.Marker = tt.marker
+			})
+			var titles []string
+			for _, doc := range docs {
+				titles = append(titles, doc.Title)
+			}
+			if !equalNames(titles, tt.titles) {
+				t.Errorf("titles = %v, want %v", titles, tt.titles)
+			}
+
+			// 包含 @catalog 但缺少标记的函数输出警告
+			var missing []string
+			for _, d := range diagnostics {
+				if d.Code == diagnostic.CodeMissingMarker {
+					missing = append(missing, d.Message)
+				}
+			}
+			marker := tt.marker
+			if marker == "" {
+				marker = config.DefaultMarker
+			}
+			var want []string
+			for _, name := range tt.missing {
+				want = append(want, fmt.Sprintf("函数 %s 的注释包含 @catalog 但缺少 %s 标记，已忽略", name, marker))
+			}
+			if !equalNames(missing, want) {
+				t.Errorf("missing-marker diagnostics = %v, want %v", missing, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// schemaError 构建结构失败的原因，code 为诊断代码，ref 为未找到的结构体引用
+type schemaError struct {
+	code    string
+	message string
+	ref     string
+}
+
+func (e *schemaError) Error() string {
+	return e.message
+}
+
+// warnf 在当前解析的注释行记录警告
+func (p *Parser) warnf(code, format string, args ...interface{}) {
+	p.diag.Warnf(p.pos, code, format, args...)
+}
+
+// docWarnf 记录接口文档的警告，位置为文档注释所在的行
+func (p *Parser) docWarnf(doc types.APIDoc, code, format string, args ...interface{}) {
+	p.diag.ReportAt(diagnostic.SeverityWarning, doc.FilePath, doc.Line, code, "", format, args...)
+}
+
+// warnSchemaError 记录构建结构失败的警告
+func (p *Parser) warnSchemaError(err error) {
+	var schemaErr *schemaError
+	if errors.As(err, &schemaErr) {
+		if schemaErr.code == diagnostic.CodeStructNotFound {
+			p.warnStructNotFound(schemaErr.ref)
+			return
+		}
+		p.warnf(schemaErr.code, "%s", schemaErr.message)
+		return
+	}
+	p.warnf(diagnostic.CodeInvalidResponse, "%v", err)
+}
+
+// warnStructNotFound 记录未找到结构体的警告，存在同名的结构体时在修复建议中列出
+func (p *Parser) warnStructNotFound(ref string) {
+	suggestion := ""
+	if similar := p.similarStructs(ref); len(similar) > 0 {
+		suggestion = "可用的同名结构体: " + strings.Join(similar, ", ")
+	}
+	p.diag.Report(diagnostic.SeverityWarning, p.pos, diagnostic.CodeStructNotFound, suggestion, "未找到结构体 %s", ref)
+}
+
+// similarStructs 返回类型名相同的结构体，如 model.User 和 dto.User
+func (p *Parser) similarStructs(ref string) []string {
+	name := types.ShortTypeName(ref)
+	if dotIndex := strings.LastIndex(name, "."); dotIndex != -1 {
+		name = name[dotIndex+1:]
+	}
+
+	var similar []string
+	for key := range p.structInfos {
+		if p.structInfos[key].Name == name {
+			similar = append(similar, key)
+		}
+	}
+	sort.Strings(similar)
+	return similar
+}
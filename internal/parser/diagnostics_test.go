@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+)
+
+func TestDiagnostics(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "diagnostics")
+
+	// swag 的 @Summary 不报告为未知标签，@remark 的内容可以从下一行开始
+	want := []string{
+		"api.go:17:1 unknown-tag 未知的注释标签 @titel，已忽略",
+		"api.go:20:1 invalid-param @param 格式错误: id query int，应为 @param 名称 位置 类型 是否必填 [说明]",
+		"api.go:21:1 invalid-param 参数 name 的位置 cookie 无效，应为 path、header、query 或 formData",
+		"api.go:31:1 invalid-method 无效的请求方法 fetch",
+		"api.go:33:1 struct-not-found 未找到结构体 UserInf",
+		"api.go:34:1 invalid-response 响应格式错误: Response{data=UserInfo，应为 结构体{字段名=结构体}",
+		"api.go:50:1 struct-not-found 未找到结构体 model.Account",
+	}
+	var got []string
+	for _, d := range diagnostics {
+		if d.Severity != diagnostic.SeverityWarning {
+			t.Errorf("severity = %s, want warning: %s", d.Severity, d)
+		}
+		got = append(got, d.Position()+" "+d.Code+" "+d.Message)
+	}
+	if !equalNames(got, want) {
+		t.Fatalf("diagnostics =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	// 存在同名结构体时在修复建议中列出
+	if suggestion := diagnostics[len(diagnostics)-1].Suggestion; suggestion != "可用的同名结构体: dto.Account" {
+		t.Errorf("suggestion = %q", suggestion)
+	}
+
+	// 缺少必填字段的接口在生成文档时报告为错误
+	dir, _ := filepath.Abs(filepath.Join("testdata", "diagnostics"))
+	cfg, err := config.LoadConfig(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(cfg)
+	if _, err := p.GenerateJSON(docs); err == nil {
+		t.Fatal("GenerateJSON() error = nil, want required-field error")
+	}
+	required := p.Diagnostics()
+	if len(required) != 1 || required[0].Severity != diagnostic.SeverityError || required[0].Code != diagnostic.CodeRequiredField ||
+		required[0].Message != "函数 DeleteUser 的title字段是必填的" || required[0].Suggestion == "" ||
+		filepath.Base(required[0].File) != "api.go" || required[0].Line != 37 {
+		t.Errorf("required diagnostics = %v", required)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		wantErr bool
+	}{
+		{"with warnings", "diagnostics", true},
+		{"without warnings", "schema", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, _ := filepath.Abs(filepath.Join("testdata", tt.dir))
+			cfg, err := config.LoadConfig(dir, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg.Scan.Strict = true
+			p := NewParser(cfg)
+			if _, err := p.ParseDir(); (err != nil) != tt.wantErr {
+				t.Errorf("ParseDir() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+)
+
+func TestEmbeddedFields(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "embedded", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			doc := findDoc(t, docs, "获取分页")
+
+			// 与 json.Marshal(Page{...}) 输出的字段一致
+			want := []string{"id", "created_at", "version", "owner", "owner.uid", "Status", "editor", "Editor", "Label", "name", "tree", "tree.value"}
+			if got := responseNames(doc.ResponseBody); !equalNames(got, want) {
+				t.Errorf("response names = %v, want %v", got, want)
+			}
+
+			tests := []struct {
+				path   string
+				typ    string
+				remark string
+			}{
+				{"id", "long", "ID"},
+				{"version", "int", "版本"},
+				{"owner", "object", ""},
+				{"Status", "int", ""},
+				{"Editor", "string", "编辑人"},
+				{"Label", "string", "带标签的名称"}, // 同一层级只有一个带json标签的字段时保留该字段
+				{"name", "string", "名称"},      // 当前结构体的字段覆盖嵌入结构体中的同名字段
+			}
+			for _, tt := range tests {
+				node := schemaAt(t, doc.ResponseSchema, tt.path)
+				if node.Type != tt.typ || node.Remark != tt.remark {
+					t.Errorf("%s = {type: %s, remark: %s}, want %+v", tt.path, node.Type, node.Remark, tt)
+				}
+			}
+		})
+	}
+}
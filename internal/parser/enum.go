@@ -0,0 +1,265 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	gotypes "go/types"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// addNamedType 记录底层类型为基本类型、切片、数组或map的命名类型，保留先于类型声明解析到的常量
+func (p *Parser) addNamedType(key, name, packageName, filePath, underlying string) {
+	namedType := p.namedTypes[key]
+	namedType.Name = name
+	namedType.Package = packageName
+	namedType.FilePath = filePath
+	namedType.Underlying = underlying
+	p.namedTypes[key] = namedType
+}
+
+// parseConstDecl 解析常量声明，将命名类型的常量记录为该类型的可选值
+// 支持 iota 和省略类型与值的隐式重复，packages模式下直接使用类型检查得到的常量值
+func (p *Parser) parseConstDecl(path string, genDecl *ast.GenDecl, keyPrefix string, typeString func(ast.Expr) string) {
+	var (
+		lastType   ast.Expr
+		lastValues []ast.Expr
+	)
+	values := make(map[string]constant.Value)
+
+	for i, spec := range genDecl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		// 省略类型和值时沿用上一行的类型和表达式
+		if valueSpec.Type != nil || len(valueSpec.Values) > 0 {
+			lastType, lastValues = valueSpec.Type, valueSpec.Values
+		}
+
+		for j, name := range valueSpec.Names {
+			var valueExpr ast.Expr
+			if j < len(lastValues) {
+				valueExpr = lastValues[j]
+			}
+
+			typeName, value, ok := p.constValue(path, name, lastType, valueExpr, i, values, typeString)
+			if !ok {
+				continue
+			}
+			values[name.Name] = value
+			if name.Name == "_" || typeName == "" {
+				continue
+			}
+
+			key := typeName
+			if !strings.Contains(typeName, ".") {
+				key = keyPrefix + "." + typeName
+			}
+			p.addEnumValue(key, types.EnumValue{
+				Name:   name.Name,
+				Value:  constantString(value),
+				Remark: specRemark(valueSpec, name.Name),
+			})
+		}
+	}
+}
+
+// constValue 计算常量的类型名和值，类型名为空表示无类型常量或基本类型常量
+func (p *Parser) constValue(path string, name *ast.Ident, typeExpr, valueExpr ast.Expr, iota int, values map[string]constant.Value, typeString func(ast.Expr) string) (string, constant.Value, bool) {
+	if pkg, exists := p.filePackages[path]; exists {
+		if obj, ok := pkg.TypesInfo.Defs[name].(*gotypes.Const); ok {
+			typeName := ""
+			if _, named := obj.Type().(*gotypes.Named); named {
+				typeName = packageTypeName(obj.Type())
+			}
+			return typeName, obj.Val(), true
+		}
+	}
+
+	if valueExpr == nil {
+		return "", nil, false
+	}
+	value, ok := evalConst(valueExpr, iota, values)
+	if !ok {
+		return "", nil, false
+	}
+
+	typeName := ""
+	if typeExpr != nil {
+		typeName = typeString(typeExpr)
+	} else if call, ok := valueExpr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		// 类型转换，如 StatusActive = Status(1)
+		typeName = typeString(call.Fun)
+	}
+	if p.isBasicType(typeName) {
+		typeName = ""
+	}
+	return typeName, value, true
+}
+
+// addEnumValue 添加命名类型的常量，同一文件被多个扫描目录包含时忽略重复的常量
+func (p *Parser) addEnumValue(key string, value types.EnumValue) {
+	namedType := p.namedTypes[key]
+	for _, existing := range namedType.Values {
+		if existing.Name == value.Name {
+			return
+		}
+	}
+	namedType.Values = append(namedType.Values, value)
+	p.namedTypes[key] = namedType
+}
+
+// evalConst 计算常量表达式的值，不支持的表达式返回 false
+func evalConst(expr ast.Expr, iota int, values map[string]constant.Value) (constant.Value, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		value := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		return value, value.Kind() != constant.Unknown
+	case *ast.Ident:
+		switch e.Name {
+		case "iota":
+			return constant.MakeInt64(int64(iota)), true
+		case "true", "false":
+			return constant.MakeBool(e.Name == "true"), true
+		}
+		value, exists := values[e.Name]
+		return value, exists
+	case *ast.ParenExpr:
+		return evalConst(e.X, iota, values)
+	case *ast.CallExpr:
+		// 类型转换只取被转换的值
+		if len(e.Args) != 1 {
+			return nil, false
+		}
+		return evalConst(e.Args[0], iota, values)
+	case *ast.UnaryExpr:
+		x, ok := evalConst(e.X, iota, values)
+		if !ok {
+			return nil, false
+		}
+		return constant.UnaryOp(e.Op, x, 0), true
+	case *ast.BinaryExpr:
+		x, ok := evalConst(e.X, iota, values)
+		if !ok {
+			return nil, false
+		}
+		y, ok := evalConst(e.Y, iota, values)
+		if !ok {
+			return nil, false
+		}
+		switch e.Op {
+		case token.SHL, token.SHR:
+			shift, ok := constant.Uint64Val(y)
+			if !ok {
+				return nil, false
+			}
+			return constant.Shift(x, e.Op, uint(shift)), true
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return constant.MakeBool(constant.Compare(x, e.Op, y)), true
+		case token.QUO:
+			// 整数常量的除法为整除
+			if x.Kind() == constant.Int && y.Kind() == constant.Int {
+				if constant.Sign(y) == 0 {
+					return nil, false
+				}
+				return constant.BinaryOp(x, token.QUO_ASSIGN, y), true
+			}
+		case token.REM:
+			if constant.Sign(y) == 0 {
+				return nil, false
+			}
+		}
+		value := constant.BinaryOp(x, e.Op, y)
+		return value, value.Kind() != constant.Unknown
+	default:
+		return nil, false
+	}
+}
+
+// constantString 将常量值转换为文档中展示的字符串
+func constantString(value constant.Value) string {
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value)
+	case constant.Float:
+		f, _ := constant.Float64Val(value)
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	default:
+		return value.ExactString()
+	}
+}
+
+// specRemark 返回常量的注释，优先使用行尾注释，文档注释去掉开头的常量名
+func specRemark(spec *ast.ValueSpec, name string) string {
+	if spec.Comment != nil {
+		return strings.Join(strings.Fields(spec.Comment.Text()), " ")
+	}
+	if spec.Doc == nil {
+		return ""
+	}
+	remark := strings.Join(strings.Fields(spec.Doc.Text()), " ")
+	return strings.TrimSpace(strings.TrimPrefix(remark, name+" "))
+}
+
+// findNamedType 在指定包的上下文中查找命名类型，返回命名类型key，key 为 包的导入路径.类型名
+// 带包名的类型按 filePath 的导入信息解析，包名可以是导入别名，如 m "example.com/app/model" 中的 m.Status
+// 只有常量而没有找到类型声明的类型（如底层类型为结构体）不是命名类型
+func (p *Parser) findNamedType(currentPackage, filePath, typeName string) (string, bool) {
+	if p.namedTypes[typeName].Underlying != "" {
+		return typeName, true
+	}
+
+	alias, name, qualified := strings.Cut(typeName, ".")
+	if !qualified {
+		key := p.packageImportPath(filePath, currentPackage) + "." + typeName
+		return key, p.namedTypes[key].Underlying != ""
+	}
+
+	importPath, exists := p.packageImports[filePath][alias]
+	if !exists {
+		return "", false
+	}
+	key := importPath + "." + name
+	if p.namedTypes[key].Underlying == "" {
+		// 找不到 go.mod 时命名类型的key以包名为前缀
+		key = path.Base(importPath) + "." + name
+	}
+	return key, p.namedTypes[key].Underlying != ""
+}
+
+// resolveNamedTypes 将类型中的命名类型替换为命名类型key，如 []m.Status 替换为 []example.com/app/model.Status
+// 已知类型替换为完整类型，如 uuid.UUID 替换为 github.com/google/uuid.UUID，没有可替换类型的类型原样返回
+func (p *Parser) resolveNamedTypes(currentPackage, filePath, goType string) string {
+	expr, err := parseTypeExpr(goType)
+	if err != nil {
+		return goType
+	}
+	resolved := p.resolveTypeExpr(expr, func(name string) (string, bool) {
+		if key, ok := p.findNamedType(currentPackage, filePath, name); ok {
+			return key, true
+		}
+		return p.findKnownType(filePath, name)
+	})
+	if resolved.String() == expr.String() {
+		return goType
+	}
+	return resolved.String()
+}
+
+// fieldNamedType 返回字段类型（去除指针、切片、数组、map后）对应的命名类型
+func (p *Parser) fieldNamedType(currentPackage, filePath, goType string) (types.NamedType, bool) {
+	expr, err := parseTypeExpr(goType)
+	if err != nil {
+		return types.NamedType{}, false
+	}
+	key, exists := p.findNamedType(currentPackage, filePath, expr.element().Name)
+	if !exists {
+		return types.NamedType{}, false
+	}
+	return p.namedTypes[key], true
+}
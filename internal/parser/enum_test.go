@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+)
+
+func TestEnums(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "enum", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			doc := findDoc(t, docs, "获取任务")
+
+			tests := []struct {
+				path    string
+				typ     string
+				enum    []string
+				remarks []string
+			}{
+				{"status", "int", []string{"0", "1", "2", "4"}, []string{"未知", "启用", "禁用", "已删除"}},
+				{"level", "string", []string{"low", "high", "mid"}, []string{"低", "高", ""}},
+				{"kinds", "array", nil, nil},
+				{"mode", "int", []string{"1", "2"}, nil},
+				{"next", "int", []string{"0", "1", "2", "4"}, []string{"未知", "启用", "禁用", "已删除"}},
+			}
+			for _, tt := range tests {
+				node := schemaAt(t, doc.ResponseSchema, tt.path)
+				if node.Type != tt.typ || !equalNames(node.Enum, tt.enum) || !equalNames(node.EnumRemarks, tt.remarks) {
+					t.Errorf("%s = {type: %s, enum: %v, remarks: %v}, want %+v", tt.path, node.Type, node.Enum, node.EnumRemarks, tt)
+				}
+			}
+			kinds := schemaAt(t, doc.ResponseSchema, "kinds").Items
+			if kinds.Type != "int" || !equalNames(kinds.Enum, []string{"0", "1", "10", "11"}) {
+				t.Errorf("kinds items = %+v", kinds)
+			}
+
+			remarks := make(map[string]string)
+			for _, param := range doc.ResponseBody {
+				remarks[param.Name] = param.Remark
+			}
+			if want := "状态（可选值 0=未知/1=启用/2=禁用/4=已删除）"; remarks["status"] != want {
+				t.Errorf("status remark = %q, want %q", remarks["status"], want)
+			}
+			if len(doc.Query) != 1 || doc.Query[0].Type != "int" || !equalNames(doc.Query[0].Enum, []string{"0", "1", "2", "4"}) {
+				t.Errorf("query = %+v", doc.Query)
+			}
+		})
+	}
+}
+
+func TestEnumImportAliases(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "enum", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			doc := findDoc(t, docs, "获取任务视图")
+
+			// 导入别名引用的命名类型，同名包中的同名类型互不覆盖
+			tests := []struct {
+				path   string
+				typ    string
+				goType string
+				enum   []string
+			}{
+				{"status", "int", "example.com/app/model.Status", []string{"0", "1", "2", "4"}},
+				{"phase", "string", "example.com/app/other/model.Status", []string{"draft", "published"}},
+			}
+			for _, tt := range tests {
+				node := schemaAt(t, doc.ResponseSchema, tt.path)
+				if node.Type != tt.typ || node.GoType != tt.goType || !equalNames(node.Enum, tt.enum) {
+					t.Errorf("%s = {type: %s, go type: %s, enum: %v}, want %+v", tt.path, node.Type, node.GoType, node.Enum, tt)
+				}
+			}
+			if levels := schemaAt(t, doc.ResponseSchema, "levels").Items; levels.Type != "string" || !equalNames(levels.Enum, []string{"low", "high", "mid"}) {
+				t.Errorf("levels items = %+v", levels)
+			}
+			if len(doc.Query) != 1 || doc.Query[0].Type != "string" {
+				t.Errorf("query = %+v", doc.Query)
+			}
+		})
+	}
+}
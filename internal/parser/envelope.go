@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// resolveEnvelope 查找配置的统一响应结构体，可以写包名或完整包路径，如 response.Response
+func (p *Parser) resolveEnvelope() {
+	p.envelopeKey = ""
+	name := p.response.Envelope
+	if name == "" {
+		return
+	}
+	if _, exists := p.structInfos[name]; exists {
+		p.envelopeKey = name
+		return
+	}
+
+	var candidates []string
+	for key, structInfo := range p.structInfos {
+		if structInfo.Package+"."+structInfo.Name == name ||
+			structInfo.PackagePath+"."+structInfo.Name == name ||
+			strings.HasSuffix(name, "/"+structInfo.PackagePath+"."+structInfo.Name) {
+			candidates = append(candidates, key)
+		}
+	}
+	sort.Strings(candidates)
+
+	switch len(candidates) {
+	case 0:
+		p.diag.Warnf(token.NoPos, diagnostic.CodeStructNotFound, "未找到统一响应结构体 %s，响应不会被包装", name)
+	case 1:
+		p.envelopeKey = candidates[0]
+	default:
+		p.diag.Report(diagnostic.SeverityWarning, token.NoPos, diagnostic.CodeStructNotFound, "可用的同名结构体: "+strings.Join(candidates, ", "),
+			"统一响应结构体 %s 匹配多个结构体，请使用完整包路径，响应不会被包装", name)
+	}
+}
+
+// isRawDoc 检查文档注释是否声明了 @raw，声明后响应和失败响应都不使用统一响应结构包装
+func isRawDoc(doc *ast.CommentGroup) bool {
+	for _, line := range docLines(doc) {
+		if strings.TrimSpace(line) == "@raw" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildBodySchema 构建响应结构，wrap 为 true 且配置了统一响应结构时，将响应类型作为业务数据字段的类型
+// 响应类型本身为统一响应结构时不再包装，如 response.Response{data=user.Info}
+func (p *Parser) buildBodySchema(responseValue string, filePath string, wrap bool) (*types.Schema, error) {
+	if !wrap || p.envelopeKey == "" || p.isEnvelope(responseValue, filePath) {
+		return p.buildResponseSchema(responseValue, filePath)
+	}
+
+	node, err := p.buildDataSchema(responseValue, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := p.buildStructSchema(p.envelopeKey)
+	schema.Ref = ""
+	schema.GoType = fmt.Sprintf("%s{%s=%s}", p.envelopeKey, p.response.DataField, responseValue)
+	replaceSchemaField(schema, p.response.DataField, node)
+	return schema, nil
+}
+
+// buildDataSchema 构建统一响应结构中业务数据字段的节点
+// 结构体、字段覆盖和泛型实例按响应结构构建，如 response.Page{items=[]user.Info}、response.Page[user.Info]
+// 切片、数组、map和指针按元素类型构建，基本类型、命名类型和已知类型按类型映射构建，如 int64、map[string]user.Info
+func (p *Parser) buildDataSchema(responseValue string, filePath string) (*types.Schema, error) {
+	expr, err := parseTypeExpr(responseValue)
+	if err != nil || strings.Contains(responseValue, "{") {
+		return p.buildResponseSchema(responseValue, filePath)
+	}
+
+	switch expr.Kind {
+	case kindPointer:
+		node, err := p.buildDataSchema(expr.Elem.String(), filePath)
+		if err != nil {
+			return nil, err
+		}
+		node.GoType = responseValue
+		node.Nullable = true
+		return node, nil
+	case kindSlice, kindArray:
+		items, err := p.buildDataSchema(expr.Elem.String(), filePath)
+		if err != nil {
+			return nil, err
+		}
+		return &types.Schema{Type: "array", GoType: responseValue, Items: items, Constraints: arrayLengthConstraints(expr)}, nil
+	case kindMap:
+		values, err := p.buildDataSchema(expr.Elem.String(), filePath)
+		if err != nil {
+			return nil, err
+		}
+		return &types.Schema{Type: "object", GoType: responseValue, AdditionalProperties: values}, nil
+	}
+
+	goType := p.resolveNamedTypes("", filePath, responseValue)
+	_, known := p.knownType(goType)
+	_, named := p.namedTypes[goType]
+	if known || named || p.isBasicType(goType) || goType == "any" || goType == "interface{}" {
+		resolved, _ := parseTypeExpr(goType)
+		return p.buildTypeSchema(newSchemaContext(1), "", resolved, types.FieldInfo{Type: goType}), nil
+	}
+	return p.buildResponseSchema(responseValue, filePath)
+}
+
+// isEnvelope 检查响应类型是否为统一响应结构，包括带字段覆盖和泛型实参的形式
+func (p *Parser) isEnvelope(responseValue string, filePath string) bool {
+	baseName := responseValue
+	if index := strings.IndexAny(baseName, "{["); index != -1 {
+		baseName = strings.TrimSpace(baseName[:index])
+	}
+	if baseName == p.envelopeKey || baseName == p.response.Envelope {
+		return true
+	}
+	structKey, err := p.resolveStructReference(baseName, filePath)
+	return err == nil && structKey == p.envelopeKey
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+)
+
+func TestEnvelope(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "envelope", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+
+			wrapped := []string{"code", "message", "data", "data.id", "data.name"}
+			tests := []struct {
+				title    string
+				response []string
+				data     string
+			}{
+				{"获取用户", wrapped, "object"},
+				{"用户列表", wrapped, "array"},
+				// 响应类型本身为统一响应结构时不再包装
+				{"已包装的响应", wrapped, "object"},
+				{"创建用户", wrapped, "object"},
+				// @raw 关闭包装
+				{"导出用户", []string{"id", "name"}, ""},
+			}
+			for _, tt := range tests {
+				doc := findDoc(t, docs, tt.title)
+				if got := responseNames(doc.ResponseBody); !equalNames(got, tt.response) {
+					t.Errorf("%s response = %v, want %v", tt.title, got, tt.response)
+				}
+				if tt.data != "" {
+					if data := schemaAt(t, doc.ResponseSchema, "data"); data.Type != tt.data || data.Remark != "业务数据" {
+						t.Errorf("%s data = {type: %s, remark: %s}, want type %s", tt.title, data.Type, data.Remark, tt.data)
+					}
+				}
+			}
+
+			failures := []struct {
+				title  string
+				status int
+				fields []string
+			}{
+				{"创建用户", 400, []string{"code", "message", "data", "data.field"}},
+				// 没有结构体的失败响应使用统一响应结构体本身
+				{"创建用户", 404, []string{"code", "message", "data"}},
+				{"导出用户", 404, nil},
+			}
+			for _, tt := range failures {
+				doc := findDoc(t, docs, tt.title)
+				found := false
+				for _, failure := range doc.Failures {
+					if failure.Status != tt.status {
+						continue
+					}
+					found = true
+					if got := responseNames(failure.Body); !equalNames(got, tt.fields) {
+						t.Errorf("%s failure %d = %v, want %v", tt.title, tt.status, got, tt.fields)
+					}
+					if (failure.Schema == nil) != (tt.fields == nil) {
+						t.Errorf("%s failure %d schema = %+v", tt.title, tt.status, failure.Schema)
+					}
+				}
+				if !found {
+					t.Errorf("%s failures = %+v, want status %d", tt.title, doc.Failures, tt.status)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvelopePayloads(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "envelope", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+
+			// 泛型实例、字段覆盖、基本类型和map作为业务数据字段的类型
+			page := []string{"code", "message", "data", "data.total", "data.items", "data.items.id", "data.items.name"}
+			tests := []struct {
+				title    string
+				response []string
+				data     string
+			}{
+				{"用户分页", page, "object"},
+				{"搜索用户", page, "object"},
+				{"用户数量", []string{"code", "message", "data"}, "long"},
+				{"用户标签", []string{"code", "message", "data", "data.*.id", "data.*.name"}, "object"},
+			}
+			for _, tt := range tests {
+				doc := findDoc(t, docs, tt.title)
+				if got := responseNames(doc.ResponseBody); !equalNames(got, tt.response) {
+					t.Errorf("%s response = %v, want %v", tt.title, got, tt.response)
+				}
+				if data := schemaAt(t, doc.ResponseSchema, "data"); data.Type != tt.data || data.Remark != "业务数据" {
+					t.Errorf("%s data = {type: %s, remark: %s}, want type %s", tt.title, data.Type, data.Remark, tt.data)
+				}
+			}
+
+			tags := schemaAt(t, findDoc(t, docs, "用户标签").ResponseSchema, "data")
+			if tags.AdditionalProperties == nil || tags.AdditionalProperties.Type != "array" {
+				t.Errorf("tags values = %+v", tags.AdditionalProperties)
+			}
+		})
+	}
+}
+
+func TestEnvelopeConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		envelope string
+		response []string
+		code     string
+	}{
+		{"package path", "example.com/app/response.Response", []string{"code", "message", "data", "data.id", "data.name"}, ""},
+		{"not found", "response.Missing", []string{"id", "name"}, diagnostic.CodeStructNotFound},
+		// 未配置或未找到时不包装，未配置时 @data 按 @response_body 解析并输出警告
+		{"disabled", "", []string{"id", "name"}, diagnostic.CodeInvalidResponse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "envelope", func(cfg *config.Config) {
+				cfg.Response.Envelope = tt.envelope
+			})
+			if got := responseNames(findDoc(t, docs, "获取用户").ResponseBody); !equalNames(got, tt.response) {
+				t.Errorf("response = %v, want %v", got, tt.response)
+			}
+			if tt.code == "" && len(diagnostics) > 0 || tt.code != "" && !hasDiagnostic(diagnostics, tt.code) {
+				t.Errorf("diagnostics = %v, want %q", diagnostics, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// resolveGenericReference 解析泛型结构体引用，如 response.Result[user.Info]，返回实例化后的结构体key
+// 实参按引用所在文件的导入信息解析，命名类型和已知类型也替换为完整的key，如 response.Result[order.Status]
+func (p *Parser) resolveGenericReference(structRef string, filePath string) (string, error) {
+	expr, err := parseTypeExpr(structRef)
+	if err != nil {
+		return "", err
+	}
+
+	resolved := p.resolveTypeExpr(expr, func(name string) (string, bool) {
+		key, err := p.resolveStructReference(name, filePath)
+		if err != nil {
+			key = name
+		}
+		if _, exists := p.structInfos[key]; exists {
+			return key, true
+		}
+		if namedKey, ok := p.findNamedType("", filePath, name); ok {
+			return namedKey, true
+		}
+		return p.findKnownType(filePath, name)
+	})
+
+	if resolved.Kind != kindNamed || len(resolved.Args) > 0 {
+		return "", fmt.Errorf("无法实例化泛型结构体 %s", structRef)
+	}
+	if _, exists := p.structInfos[resolved.Name]; !exists {
+		return "", fmt.Errorf("无法找到泛型结构体 %s", structRef)
+	}
+	return resolved.Name, nil
+}
+
+// resolveTypeExpr 将类型表达式中的命名类型解析为结构体key，泛型类型会被实例化
+// 无法解析的类型（如基本类型）保持原样
+func (p *Parser) resolveTypeExpr(t *typeExpr, lookup func(name string) (string, bool)) *typeExpr {
+	result := *t
+	if t.Kind != kindNamed {
+		if t.Key != nil {
+			result.Key = p.resolveTypeExpr(t.Key, lookup)
+		}
+		result.Elem = p.resolveTypeExpr(t.Elem, lookup)
+		return &result
+	}
+
+	if len(t.Args) > 0 {
+		result.Args = make([]*typeExpr, len(t.Args))
+		for i, arg := range t.Args {
+			result.Args[i] = p.resolveTypeExpr(arg, lookup)
+		}
+	}
+
+	baseKey, exists := lookup(t.Name)
+	if !exists {
+		return &result
+	}
+	result.Name = baseKey
+
+	if len(result.Args) > 0 {
+		if key, ok := p.instantiate(baseKey, result.Args); ok {
+			return &typeExpr{Kind: kindNamed, Name: key}
+		}
+	}
+	return &result
+}
+
+// instantiate 使用实参实例化泛型结构体，返回实例的结构体key，如 response.Result[user.Info]
+func (p *Parser) instantiate(baseKey string, args []*typeExpr) (string, bool) {
+	base, exists := p.structInfos[baseKey]
+	if !exists || len(base.TypeParams) != len(args) {
+		return "", false
+	}
+
+	argNames := make([]string, len(args))
+	params := make(map[string]*typeExpr)
+	for i, arg := range args {
+		argNames[i] = arg.String()
+		params[base.TypeParams[i]] = arg
+	}
+
+	key := baseKey + "[" + strings.Join(argNames, ",") + "]"
+	if _, exists := p.structInfos[key]; exists {
+		return key, true
+	}
+
+	instance := types.StructInfo{
+		Name:        base.Name + "[" + strings.Join(argNames, ",") + "]",
+		Package:     base.Package,
+		PackagePath: base.PackagePath,
+		FilePath:    base.FilePath,
+	}
+
+	// 先占位，避免字段引用自身时重复实例化
+	p.structInfos[key] = instance
+
+	instance.Fields = p.instantiateFields(base.Package, base.FilePath, base.Fields, params)
+
+	p.structInfos[key] = instance
+	return key, true
+}
+
+// instantiateFields 将字段类型中的类型参数替换为实参，包括匿名结构体的子字段
+func (p *Parser) instantiateFields(currentPackage, filePath string, fields []types.FieldInfo, params map[string]*typeExpr) []types.FieldInfo {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	instanceFields := make([]types.FieldInfo, 0, len(fields))
+	for _, field := range fields {
+		if expr, err := parseTypeExpr(field.Type); err == nil {
+			substituted := expr.substitute(params)
+			field.Ref = p.fieldRef(currentPackage, filePath, substituted)
+			field.Type = p.resolveNamedTypes(currentPackage, filePath, substituted.String())
+		}
+		field.Constraints = p.parseConstraints(currentPackage, field.Tag, field.Type)
+		field.Fields = p.instantiateFields(currentPackage, filePath, field.Fields, params)
+		instanceFields = append(instanceFields, field)
+	}
+	return instanceFields
+}
+
+// fieldRef 返回字段类型（去除指针、切片、数组、map后）对应的结构体key，非结构体返回空
+// 泛型实参中的命名类型和已知类型同样按字段所在文件解析，如 response.Result[order.Status]
+func (p *Parser) fieldRef(currentPackage, filePath string, expr *typeExpr) string {
+	resolved := p.resolveTypeExpr(expr.element(), func(name string) (string, bool) {
+		if structKey, ok := p.findStructKey(currentPackage, filePath, name); ok {
+			return structKey, true
+		}
+		if namedKey, ok := p.findNamedType(currentPackage, filePath, name); ok {
+			return namedKey, true
+		}
+		return p.findKnownType(filePath, name)
+	})
+	if resolved.Kind != kindNamed || len(resolved.Args) > 0 {
+		return ""
+	}
+
+	structInfo, exists := p.structInfos[resolved.Name]
+	if !exists || len(structInfo.TypeParams) > 0 {
+		return ""
+	}
+	return resolved.Name
+}
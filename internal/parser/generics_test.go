@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+func TestGenerics(t *testing.T) {
+	tests := []struct {
+		title string
+		body  bool
+		want  []string
+	}{
+		{"用户列表", false, []string{"code", "data", "data.total", "data.list", "data.list.id", "data.list.name"}},
+		{"保存用户", true, []string{"key", "value", "value.id", "value.name"}},
+		{"用户分页", false, []string{"total", "list", "list.id", "list.name"}},
+	}
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "generics", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			for _, tt := range tests {
+				doc := findDoc(t, docs, tt.title)
+				schema := doc.ResponseSchema
+				if tt.body {
+					schema = doc.BodySchema
+				}
+				var got []string
+				schema.Walk(func(path string, node *types.Schema) {
+					got = append(got, path)
+				})
+				if !equalNames(got, tt.want) {
+					t.Errorf("%s fields = %v, want %v", tt.title, got, tt.want)
+				}
+			}
+
+			// 类型参数替换为实参后按实参的类型展示
+			list := findDoc(t, docs, "用户列表")
+			if node := schemaAt(t, list.ResponseSchema, "data.list.id"); node.Type != "long" {
+				t.Errorf("data.list.id type = %s, want long", node.Type)
+			}
+			save := findDoc(t, docs, "保存用户")
+			if node := schemaAt(t, save.BodySchema, "key"); node.Type != "string" {
+				t.Errorf("key type = %s, want string", node.Type)
+			}
+
+			// 调用处的命名类型实参保留可选值
+			status := findDoc(t, docs, "用户状态")
+			if node := schemaAt(t, status.ResponseSchema, "data"); node.Type != "int" || !equalNames(node.Enum, []string{"1", "2"}) {
+				t.Errorf("data = {type: %s, enum: %v}, want {type: int, enum: [1 2]}", node.Type, node.Enum)
+			}
+			view := findDoc(t, docs, "状态视图")
+			if node := schemaAt(t, view.ResponseSchema, "current.data"); node.Type != "int" || !equalNames(node.Enum, []string{"1", "2"}) {
+				t.Errorf("current.data = {type: %s, enum: %v}, want {type: int, enum: [1 2]}", node.Type, node.Enum)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// wellKnownTypes 常用标准库和第三方库类型的映射，key 为 包的导入路径.类型名，避免匹配到同名的本地包
+// 映射的类型为Go基本类型，按其JSON序列化后的形式选择，序列化为对象的类型（如 sql.NullString）不在其中
+var wellKnownTypes = map[string]config.TypeMapping{
+	"[]byte":                                              {Type: "string", Format: "byte"}, // base64编码
+	"[]uint8":                                             {Type: "string", Format: "byte"},
+	"time.Time":                                           {Type: "string", Format: "date-time"},
+	"time.Duration":                                       {Type: "int64"},
+	"encoding/json.RawMessage":                            {Type: "any"},
+	"encoding/json.Number":                                {Type: "float64"},
+	"net.IP":                                              {Type: "string"},
+	"github.com/google/uuid.UUID":                         {Type: "string", Format: "uuid"},
+	"github.com/google/uuid.NullUUID":                     {Type: "string", Format: "uuid"},
+	"github.com/gofrs/uuid.UUID":                          {Type: "string", Format: "uuid"},
+	"github.com/shopspring/decimal.Decimal":               {Type: "string", Format: "decimal"},
+	"github.com/shopspring/decimal.NullDecimal":           {Type: "string", Format: "decimal"},
+	"gopkg.in/guregu/null.v4.String":                      {Type: "string"},
+	"gopkg.in/guregu/null.v4.Int":                         {Type: "int64"},
+	"gopkg.in/guregu/null.v4.Float":                       {Type: "float64"},
+	"gopkg.in/guregu/null.v4.Bool":                        {Type: "bool"},
+	"gopkg.in/guregu/null.v4.Time":                        {Type: "string", Format: "date-time"},
+	"go.mongodb.org/mongo-driver/bson/primitive.ObjectID": {Type: "string"},
+}
+
+// knownType 返回Go类型的映射，优先级依次为配置的映射、@type 注释、序列化方法和内置映射
+// 配置的映射依次使用完整类型和去除包路径后的类型名查找，如 github.com/google/uuid.UUID 和 uuid.UUID
+// 内置映射只按完整类型查找
+func (p *Parser) knownType(goType string) (config.TypeMapping, bool) {
+	if goType == "" {
+		return config.TypeMapping{}, false
+	}
+	if mapping, exists := p.typeMapping[goType]; exists {
+		return mapping, true
+	}
+	if mapping, exists := p.typeMapping[types.ShortTypeName(goType)]; exists {
+		return mapping, true
+	}
+	if mapping, exists := p.typeAnnotations[goType]; exists {
+		return mapping, true
+	}
+	if method, exists := p.marshalers[goType]; exists {
+		return marshalerMappings[method], true
+	}
+	mapping, exists := wellKnownTypes[goType]
+	return mapping, exists
+}
+
+// findKnownType 按文件的导入信息将带包名的类型转换为完整类型，如 json.RawMessage 转换为 encoding/json.RawMessage
+// 只返回配置或内置了映射的类型，包名可以是导入别名
+func (p *Parser) findKnownType(filePath, typeName string) (string, bool) {
+	alias, name, qualified := strings.Cut(typeName, ".")
+	if !qualified {
+		return "", false
+	}
+	importPath, exists := p.packageImports[filePath][alias]
+	if !exists {
+		return "", false
+	}
+	key := importPath + "." + name
+	_, known := p.knownType(key)
+	return key, known
+}
+
+// resolveKnownTypes 将类型中带包名的已知类型替换为完整类型，如 []uuid.UUID 替换为 []github.com/google/uuid.UUID
+func (p *Parser) resolveKnownTypes(filePath, goType string) string {
+	expr, err := parseTypeExpr(goType)
+	if err != nil {
+		return goType
+	}
+	resolved := p.resolveTypeExpr(expr, func(name string) (string, bool) {
+		return p.findKnownType(filePath, name)
+	})
+	if resolved.String() == expr.String() {
+		return goType
+	}
+	return resolved.String()
+}
+
+// knownRequestType 返回映射对应的请求参数类型
+func (p *Parser) knownRequestType(mapping config.TypeMapping) string {
+	switch {
+	case mapping.Type == "number":
+		return "double"
+	case p.isBasicType(mapping.Type):
+		return p.mapGoTypeToRequestType(mapping.Type)
+	default:
+		return mapping.Type
+	}
+}
+
+// knownResponseType 返回映射对应的响应参数类型
+func (p *Parser) knownResponseType(mapping config.TypeMapping) string {
+	switch {
+	case mapping.Type == "float" || mapping.Type == "double":
+		return "number"
+	case p.isBasicType(mapping.Type):
+		return p.mapGoTypeToResponseType(mapping.Type)
+	default:
+		return mapping.Type
+	}
+}
+
+// knownTypeSchema 返回映射类型的节点，keys 为类型的候选写法，如字段类型和解析后的结构体key
+func (p *Parser) knownTypeSchema(keys ...string) (*types.Schema, bool) {
+	for _, key := range keys {
+		if mapping, exists := p.knownType(key); exists {
+			return &types.Schema{
+				Type:        p.knownResponseType(mapping),
+				GoType:      key,
+				Constraints: types.Constraints{Format: mapping.Format},
+			}, true
+		}
+	}
+	return nil, false
+}
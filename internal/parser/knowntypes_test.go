@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+)
+
+func TestKnownTypes(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "knowntypes", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			doc := findDoc(t, docs, "获取订单")
+
+			// 配置的映射优先于内置映射，命名类型按底层类型展示，内置映射不匹配同名的本地包
+			tests := []struct {
+				path   string
+				typ    string
+				format string
+			}{
+				{"id", "string", "uuid"},
+				{"user_id", "long", ""},
+				{"amount", "string", "decimal"},
+				{"price", "long", "cents"},
+				{"created_at", "long", "unix"},
+				{"timeout", "long", ""},
+				{"trace_id", "array", ""},
+				{"extra", "any", ""},
+				{"data", "string", "byte"},
+				{"tags", "array", ""},
+				{"scores", "object", ""},
+			}
+			for _, tt := range tests {
+				node := schemaAt(t, doc.ResponseSchema, tt.path)
+				if node.Type != tt.typ || node.Format != tt.format || len(node.Children) > 0 {
+					t.Errorf("%s = {type: %s, format: %s, children: %d}, want %+v", tt.path, node.Type, node.Format, len(node.Children), tt)
+				}
+			}
+			if tags := schemaAt(t, doc.ResponseSchema, "tags"); tags.Items == nil || tags.Items.Type != "string" {
+				t.Errorf("tags items = %+v", tags.Items)
+			}
+			if scores := schemaAt(t, doc.ResponseSchema, "scores"); scores.AdditionalProperties == nil || scores.AdditionalProperties.Type != "int" {
+				t.Errorf("scores values = %+v", scores.AdditionalProperties)
+			}
+
+			queryTests := []struct {
+				name   string
+				typ    string
+				format string
+			}{
+				{"user_id", "long", ""},
+				{"since", "long", "unix"},
+				{"tags", "array", ""},
+				{"trace", "string", "uuid"},
+			}
+			if len(doc.Query) != len(queryTests) {
+				t.Fatalf("query = %+v", doc.Query)
+			}
+			for i, tt := range queryTests {
+				param := doc.Query[i]
+				if param.Name != tt.name || param.Type != tt.typ || param.Format != tt.format {
+					t.Errorf("query %d = %+v, want %+v", i, param, tt)
+				}
+			}
+		})
+	}
+}
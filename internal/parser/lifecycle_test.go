@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+)
+
+func TestLifecycle(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "lifecycle")
+
+	tests := []struct {
+		title      string
+		deprecated bool
+		note       string
+		since      string
+		sunset     string
+	}{
+		{"用户列表", true, "请使用 /v2/users", "v1.2", "2027-01-01"},
+		{"用户列表V2", true, "", "", ""},
+	}
+	for _, tt := range tests {
+		doc := findDoc(t, docs, tt.title)
+		if doc.Deprecated != tt.deprecated || doc.DeprecatedNote != tt.note || doc.Since != tt.since || doc.Sunset != tt.sunset {
+			t.Errorf("%s = {deprecated: %t, note: %s, since: %s, sunset: %s}, want %+v", tt.title, doc.Deprecated, doc.DeprecatedNote, doc.Since, doc.Sunset, tt)
+		}
+	}
+
+	// 格式错误的计划下线日期被忽略并输出警告
+	if len(diagnostics) != 1 || diagnostics[0].Code != diagnostic.CodeInvalidSunset {
+		t.Errorf("diagnostics = %v", diagnostics)
+	}
+
+	doc := findDoc(t, docs, "用户列表")
+	fields := []struct {
+		path       string
+		deprecated bool
+		note       string
+		remark     string
+	}{
+		{"name", true, "使用 nickname 代替", "已废弃：使用 nickname 代替"},
+		{"nickname", false, "", "昵称"},
+		{"old", true, "下个版本删除", "旧字段（已废弃：下个版本删除）"},
+		{"legacy", true, "不再返回", "已废弃：不再返回"},
+	}
+	remarks := make(map[string]string)
+	for _, param := range doc.ResponseBody {
+		remarks[param.Name] = param.Remark
+	}
+	for _, tt := range fields {
+		node := schemaAt(t, doc.ResponseSchema, tt.path)
+		if node.Deprecated != tt.deprecated || node.DeprecatedNote != tt.note {
+			t.Errorf("%s = {deprecated: %t, note: %s}, want %+v", tt.path, node.Deprecated, node.DeprecatedNote, tt)
+		}
+		if remarks[tt.path] != tt.remark {
+			t.Errorf("%s remark = %q, want %q", tt.path, remarks[tt.path], tt.remark)
+		}
+	}
+
+	if len(doc.Query) != 2 || doc.Query[0].Deprecated || !doc.Query[1].Deprecated || doc.Query[1].Remark != "关键字（已废弃：使用 keyword）" {
+		t.Errorf("query = %+v", doc.Query)
+	}
+}
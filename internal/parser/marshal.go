@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"go/ast"
+	gotypes "go/types"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"golang.org/x/tools/go/packages"
+)
+
+// marshalerMappings 自定义序列化方法对应的类型映射
+// MarshalJSON 的输出无法从源码推断，映射为 any；MarshalText 的输出为JSON字符串
+var marshalerMappings = map[string]config.TypeMapping{
+	"MarshalJSON": {Type: "any"},
+	"MarshalText": {Type: "string"},
+}
+
+// parseTypeAnnotation 解析类型声明上的 @type 注释，如 @type string date-time
+// 用于声明实现了 MarshalJSON 等方法的类型序列化后的文档类型和格式
+func (p *Parser) parseTypeAnnotation(key string, genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) {
+	doc := typeSpec.Doc
+	if doc == nil && len(genDecl.Specs) == 1 {
+		doc = genDecl.Doc
+	}
+	if doc == nil {
+		return
+	}
+
+	for _, line := range docLines(doc) {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@type ") {
+			continue
+		}
+		parts := strings.Fields(strings.TrimPrefix(line, "@type "))
+		if len(parts) == 0 {
+			continue
+		}
+		mapping := config.TypeMapping{Type: parts[0]}
+		if len(parts) > 1 {
+			mapping.Format = parts[1]
+		}
+		p.typeAnnotations[key] = mapping
+	}
+}
+
+// parseMarshalMethod 记录声明了 MarshalJSON 或 MarshalText 方法的类型，MarshalJSON 优先
+func (p *Parser) parseMarshalMethod(funcDecl *ast.FuncDecl, keyPrefix string) {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return
+	}
+	if _, exists := marshalerMappings[funcDecl.Name.Name]; !exists {
+		return
+	}
+
+	typeName := receiverTypeName(funcDecl.Recv.List[0].Type)
+	if typeName == "" {
+		return
+	}
+	p.addMarshaler(keyPrefix+"."+typeName, funcDecl.Name.Name)
+}
+
+// parsePackageMarshalers 基于类型检查结果记录实现了序列化方法的类型，包括通过嵌入字段提升的方法
+func (p *Parser) parsePackageMarshalers(pkg *packages.Package) {
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*gotypes.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		methodSet := gotypes.NewMethodSet(gotypes.NewPointer(typeName.Type()))
+		for method := range marshalerMappings {
+			if methodSet.Lookup(nil, method) != nil {
+				p.addMarshaler(pkg.PkgPath+"."+name, method)
+			}
+		}
+	}
+}
+
+// addMarshaler 记录类型的序列化方法，同时实现两种方法时以 MarshalJSON 为准
+func (p *Parser) addMarshaler(key, method string) {
+	if p.marshalers[key] == "MarshalJSON" {
+		return
+	}
+	p.marshalers[key] = method
+}
+
+// receiverTypeName 返回方法接收者的类型名，如 *User、Page[T] 均返回类型名本身
+func receiverTypeName(expr ast.Expr) string {
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
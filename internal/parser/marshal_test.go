@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+)
+
+func TestMarshalers(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "marshal", useResolver(resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			doc := findDoc(t, docs, "获取订单")
+
+			tests := []struct {
+				path   string
+				typ    string
+				format string
+			}{
+				{"id", "string", "int64"},
+				{"count", "string", ""},
+				{"price", "string", ""},
+				{"paid", "string", ""},
+				{"name", "string", ""},
+				{"version", "string", "semver"},
+				{"payload", "any", ""},
+				{"level", "string", ""},
+				{"levels", "array", ""},
+				{"owner.uid", "string", "int64"},
+			}
+			for _, tt := range tests {
+				node := schemaAt(t, doc.ResponseSchema, tt.path)
+				if node.Type != tt.typ || node.Format != tt.format {
+					t.Errorf("%s = {type: %s, format: %s}, want %+v", tt.path, node.Type, node.Format, tt)
+				}
+			}
+			if levels := schemaAt(t, doc.ResponseSchema, "levels"); levels.Items == nil || levels.Items.Type != "string" {
+				t.Errorf("levels items = %+v", levels.Items)
+			}
+			// packages 模式下识别通过嵌入字段提升的方法
+			if stamp := schemaAt(t, doc.ResponseSchema, "stamp"); resolver == config.ResolverPackages && stamp.Type != "any" {
+				t.Errorf("stamp = {type: %s, format: %s}, want any", stamp.Type, stamp.Format)
+			}
+			for _, field := range []string{"version", "payload"} {
+				if node := schemaAt(t, doc.ResponseSchema, field); len(node.Children) > 0 {
+					t.Errorf("%s children = %+v", field, node.Children)
+				}
+			}
+		})
+	}
+}
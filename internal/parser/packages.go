@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	gotypes "go/types"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"golang.org/x/tools/go/packages"
+)
+
+// packagesLoadMode go/packages 加载模式，需要语法树和类型检查结果
+const packagesLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+// parseStructsInPackages 使用 go/packages 加载目录下的所有包，并基于类型检查结果解析结构体定义
+// 结构体key和字段类型均使用完整包路径，如 example/internal/server/model/user.User
+func (p *Parser) parseStructsInPackages(dir string) error {
+	cfg := &packages.Config{
+		Mode: packagesLoadMode,
+		Dir:  dir,
+		Fset: p.fset,
+	}
+
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return fmt.Errorf("加载包失败: %v", err)
+	}
+
+	for _, pkg := range pkgs {
+		for _, pkgErr := range pkg.Errors {
+			p.diag.Add(packageErrorDiagnostic(pkg.PkgPath, pkgErr))
+		}
+
+		// 跳过vendor目录（除非明确包含）
+		if !p.includeVendor && strings.Contains(pkg.PkgPath, "/vendor/") {
+			continue
+		}
+
+		if pkg.TypesInfo == nil || len(pkg.Syntax) != len(pkg.CompiledGoFiles) {
+			continue
+		}
+
+		p.parsePackageMarshalers(pkg)
+
+		typeString := p.packageTypeString(pkg)
+		for i, file := range pkg.Syntax {
+			path := filepath.Clean(pkg.CompiledGoFiles[i])
+			p.filePackages[path] = pkg
+			p.parsePackageImports(path, file, pkg)
+			p.parseStructsInFile(path, file, pkg.PkgPath, pkg.Name, pkg.PkgPath, typeString)
+		}
+	}
+
+	return nil
+}
+
+// packageErrorDiagnostic 将加载包的错误转换为诊断信息，错误位置的格式为 file:line:col 或 file:line
+func packageErrorDiagnostic(pkgPath string, pkgErr packages.Error) diagnostic.Diagnostic {
+	d := diagnostic.Diagnostic{
+		Severity: diagnostic.SeverityWarning,
+		Code:     diagnostic.CodeLoadPackage,
+		Message:  fmt.Sprintf("加载包 %s 出错: %s", pkgPath, pkgErr.Msg),
+	}
+
+	position := pkgErr.Pos
+	var numbers []int
+	for len(numbers) < 2 {
+		colonIndex := strings.LastIndex(position, ":")
+		if colonIndex == -1 {
+			break
+		}
+		number, err := strconv.Atoi(position[colonIndex+1:])
+		if err != nil {
+			break
+		}
+		numbers = append([]int{number}, numbers...)
+		position = position[:colonIndex]
+	}
+	if position != "" && position != "-" {
+		d.File = position
+	}
+	if len(numbers) > 0 {
+		d.Line = numbers[0]
+	}
+	if len(numbers) > 1 {
+		d.Column = numbers[1]
+	}
+	return d
+}
+
+// packageTypeString 返回基于类型检查结果的类型字符串转换函数，类型检查失败时退回到AST解析
+func (p *Parser) packageTypeString(pkg *packages.Package) func(ast.Expr) string {
+	return func(expr ast.Expr) string {
+		if t := pkg.TypesInfo.TypeOf(expr); t != nil && t != gotypes.Typ[gotypes.Invalid] {
+			return packageTypeName(t)
+		}
+		return p.getTypeString(expr)
+	}
+}
+
+// packageTypeName 将类型检查得到的类型转换为类型字符串，命名类型使用完整包路径
+// 匿名结构体、接口等类型的写法与AST解析保持一致，如 struct{}、interface{}
+func packageTypeName(t gotypes.Type) string {
+	switch t := t.(type) {
+	case *gotypes.Pointer:
+		return "*" + packageTypeName(t.Elem())
+	case *gotypes.Slice:
+		return "[]" + packageTypeName(t.Elem())
+	case *gotypes.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), packageTypeName(t.Elem()))
+	case *gotypes.Map:
+		return "map[" + packageTypeName(t.Key()) + "]" + packageTypeName(t.Elem())
+	case *gotypes.Struct:
+		return "struct{}"
+	case *gotypes.Interface:
+		return "interface{}"
+	case *gotypes.Chan:
+		return "chan " + packageTypeName(t.Elem())
+	case *gotypes.Signature:
+		return "func()"
+	default:
+		return gotypes.TypeString(t, func(other *gotypes.Package) string {
+			return other.Path()
+		})
+	}
+}
+
+// parsePackageImports 解析文件的导入信息，未指定别名的导入使用类型检查得到的真实包名
+func (p *Parser) parsePackageImports(filePath string, file *ast.File, pkg *packages.Package) {
+	imports := make(map[string]string)
+
+	for _, imp := range file.Imports {
+		importPath := strings.Trim(imp.Path.Value, `"`)
+
+		var alias string
+		if imp.Name != nil {
+			alias = imp.Name.Name
+		} else if pkgName := pkg.TypesInfo.PkgNameOf(imp); pkgName != nil {
+			alias = pkgName.Imported().Name()
+		} else {
+			parts := strings.Split(importPath, "/")
+			alias = parts[len(parts)-1]
+		}
+
+		imports[alias] = importPath
+	}
+
+	p.packageImports[filePath] = imports
+}
+
+// resolvePackageReference 基于类型检查结果解析结构体引用，返回唯一的 "完整包路径.类型名"
+func (p *Parser) resolvePackageReference(structRef string, filePath string) (string, error) {
+	// 已经是完整的结构体key
+	if _, exists := p.structInfos[structRef]; exists {
+		return structRef, nil
+	}
+
+	filePath = filepath.Clean(filePath)
+	pkg, exists := p.filePackages[filePath]
+	if !exists {
+		return "", fmt.Errorf("文件 %s 不在已加载的包中", filePath)
+	}
+
+	pkgPath := pkg.PkgPath
+	scope := pkg.Types.Scope()
+	typeName := structRef
+
+	if dotIndex := strings.Index(structRef, "."); dotIndex != -1 {
+		packageAlias := structRef[:dotIndex]
+		typeName = structRef[dotIndex+1:]
+
+		importPath, exists := p.packageImports[filePath][packageAlias]
+		if !exists {
+			return "", fmt.Errorf("无法找到包别名 %s 对应的导入路径", packageAlias)
+		}
+
+		var imported *gotypes.Package
+		for _, candidate := range pkg.Types.Imports() {
+			if candidate.Path() == importPath {
+				imported = candidate
+				break
+			}
+		}
+		if imported == nil {
+			return "", fmt.Errorf("包 %s 未被类型检查加载", importPath)
+		}
+
+		pkgPath = importPath
+		scope = imported.Scope()
+	}
+
+	if _, ok := scope.Lookup(typeName).(*gotypes.TypeName); !ok {
+		return "", fmt.Errorf("无法在包 %s 中找到类型 %s", pkgPath, typeName)
+	}
+
+	return pkgPath + "." + typeName, nil
+}
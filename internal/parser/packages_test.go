@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+)
+
+// useResolver 切换结构体解析模式
+func useResolver(resolver string) func(*config.Config) {
+	return func(cfg *config.Config) {
+		cfg.Scan.Resolver = resolver
+	}
+}
+
+func TestPackagesResolver(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "packages")
+	if len(diagnostics) > 0 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+
+	// 包名相同的两个包中的同名结构体按完整包路径区分
+	detail := findDoc(t, docs, "获取详情")
+	tests := []struct {
+		path string
+		ref  string
+	}{
+		{"user", "example.com/app/model.User"},
+		{"admin", "example.com/app/admin/model.User"},
+	}
+	for _, tt := range tests {
+		if node := schemaAt(t, detail.ResponseSchema, tt.path); node.Ref != tt.ref {
+			t.Errorf("%s ref = %s, want %s", tt.path, node.Ref, tt.ref)
+		}
+	}
+	want := []string{"user", "user.name", "admin", "admin.role"}
+	if got := responseNames(detail.ResponseBody); !equalNames(got, want) {
+		t.Errorf("response names = %v, want %v", got, want)
+	}
+
+	admin := findDoc(t, docs, "获取管理员")
+	if admin.ResponseSchema.Ref != "example.com/app/admin/model.User" || admin.ResponseSchema.Field("role") == nil {
+		t.Errorf("admin response = %+v", admin.ResponseSchema)
+	}
+}
+
+func TestImportAlias(t *testing.T) {
+	tests := []struct {
+		resolver string
+		ref      string
+	}{
+		{config.ResolverAST, "model.Info"},
+		{config.ResolverPackages, "example.com/app/model.Info"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "alias", useResolver(tt.resolver))
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			doc := findDoc(t, docs, "获取详情")
+			if node := schemaAt(t, doc.ResponseSchema, "info"); node.Ref != tt.ref {
+				t.Errorf("info ref = %s, want %s", node.Ref, tt.ref)
+			}
+			want := []string{"info", "info.id", "infos", "infos.id"}
+			if got := responseNames(doc.ResponseBody); !equalNames(got, want) {
+				t.Errorf("response names = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// typeGroup 类型声明上 @group 注释定义的参数组
+type typeGroup struct {
+	structKey string
+	location  string
+}
+
+// groupParam 参数组中的参数及其位置
+type groupParam struct {
+	location string
+	param    types.RequestParam
+}
+
+// parseGroupAnnotation 解析类型声明上的 @group 注释，格式为 @group [名称] [位置]
+// 名称默认为类型名，位置默认为 query，字段按对应位置的绑定标签展开
+func (p *Parser) parseGroupAnnotation(key string, genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) {
+	doc := typeSpec.Doc
+	if doc == nil && len(genDecl.Specs) == 1 {
+		doc = genDecl.Doc
+	}
+	if doc == nil {
+		return
+	}
+
+	for _, line := range commentLines(doc) {
+		text := strings.TrimSpace(line.text)
+		if text != "@group" && !strings.HasPrefix(text, "@group ") {
+			continue
+		}
+		p.pos = line.pos
+
+		parts := strings.Fields(strings.TrimPrefix(text, "@group"))
+		group := typeGroup{structKey: key, location: "query"}
+		name := typeSpec.Name.Name
+		if len(parts) > 0 {
+			name = parts[0]
+		}
+		if len(parts) > 1 {
+			group.location = parts[1]
+		}
+		if _, exists := bindingTags[group.location]; !exists {
+			p.warnf(diagnostic.CodeParamGroup, "参数组 %s 的位置 %s 无效，应为 header、query、path 或 formData", name, group.location)
+			continue
+		}
+
+		if _, exists := p.params.Groups[name]; exists {
+			p.warnf(diagnostic.CodeParamGroup, "参数组 %s 已在配置文件中定义，已忽略类型 %s 上的定义", name, typeSpec.Name.Name)
+			continue
+		}
+		if existing, exists := p.typeGroups[name]; exists && existing.structKey != key {
+			p.warnf(diagnostic.CodeParamGroup, "参数组 %s 重复定义，已忽略类型 %s 上的定义", name, typeSpec.Name.Name)
+			continue
+		}
+		p.typeGroups[name] = group
+	}
+}
+
+// groupParams 返回参数组中的参数，配置文件中的定义优先
+func (p *Parser) groupParams(name string) ([]groupParam, bool) {
+	if definitions, exists := p.params.Groups[name]; exists {
+		params := make([]groupParam, 0, len(definitions))
+		for _, definition := range definitions {
+			params = append(params, groupParam{
+				location: definition.In,
+				param: types.RequestParam{
+					Name:    definition.Name,
+					Type:    p.mapGoTypeToRequestType(definition.Type),
+					Require: fmt.Sprintf("%t", definition.Required || definition.In == "path"),
+					Remark:  definition.Remark,
+				},
+			})
+		}
+		return params, true
+	}
+
+	group, exists := p.typeGroups[name]
+	if !exists {
+		return nil, false
+	}
+	var params []groupParam
+	for _, param := range p.bindingParams(group.structKey, group.location, make(map[string]bool)) {
+		params = append(params, groupParam{location: group.location, param: param})
+	}
+	return params, true
+}
+
+// groupNames 解析 @use 和 @exclude 标签中的名称，多个名称以逗号或空格分隔
+func groupNames(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// appendUse 解析 @use 标签，未定义的参数组输出警告并忽略
+func (p *Parser) appendUse(use []string, value string) []string {
+	for _, name := range groupNames(value) {
+		if _, exists := p.groupParams(name); !exists {
+			p.warnf(diagnostic.CodeParamGroup, "参数组 %s 未定义，已忽略", name)
+			continue
+		}
+		use = append(use, name)
+	}
+	return use
+}
+
+// appendExclude 解析 @exclude 标签，名称可以是参数组或参数组中的参数，都不匹配时输出警告
+func (p *Parser) appendExclude(exclude []string, value string) []string {
+	for _, name := range groupNames(value) {
+		if !p.isGroupOrGroupParam(name) {
+			p.warnf(diagnostic.CodeParamGroup, "@exclude %s 没有匹配的参数组或参数", name)
+		}
+		exclude = append(exclude, name)
+	}
+	return exclude
+}
+
+// isGroupOrGroupParam 检查名称是否为参数组或参数组中的参数
+func (p *Parser) isGroupOrGroupParam(name string) bool {
+	var groups []string
+	for group := range p.params.Groups {
+		groups = append(groups, group)
+	}
+	for group := range p.typeGroups {
+		groups = append(groups, group)
+	}
+
+	for _, group := range groups {
+		if group == name {
+			return true
+		}
+		params, _ := p.groupParams(group)
+		for _, param := range params {
+			if param.param.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkGlobalParams 检查全局参数引用的参数组是否已定义
+func (p *Parser) checkGlobalParams() {
+	for _, global := range p.params.Globals {
+		for _, name := range global.Use {
+			if _, exists := p.groupParams(name); !exists {
+				p.diag.Warnf(token.NoPos, diagnostic.CodeParamGroup, "全局参数引用的参数组 %s 未定义，已忽略", name)
+			}
+		}
+	}
+}
+
+// applyParamGroups 将全局参数和 @use 引用的参数组添加到接口参数之前
+// 已排除的参数组和参数不会添加，接口自身声明了同名参数时使用接口的参数
+func (p *Parser) applyParamGroups(apiDoc *types.APIDoc, use, exclude []string) {
+	var names []string
+	for _, global := range p.params.Globals {
+		if catalogHasPrefix(apiDoc.Catalog, global.Catalog) {
+			names = append(names, global.Use...)
+		}
+	}
+	names = append(names, use...)
+	if len(names) == 0 {
+		return
+	}
+
+	excluded := make(map[string]bool)
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+
+	applied := make(map[string]bool)
+	var path, header, query, formData []types.RequestParam
+	for _, name := range names {
+		if applied[name] || excluded[name] {
+			continue
+		}
+		applied[name] = true
+
+		params, _ := p.groupParams(name)
+		for _, param := range params {
+			if excluded[param.param.Name] {
+				continue
+			}
+			switch param.location {
+			case "path":
+				path = appendGroupParam(path, apiDoc.Path, param.param)
+			case "header":
+				header = appendGroupParam(header, apiDoc.Header, param.param)
+			case "query":
+				query = appendGroupParam(query, apiDoc.Query, param.param)
+			case "formData":
+				formData = appendGroupParam(formData, apiDoc.FormData, param.param)
+			}
+		}
+	}
+
+	apiDoc.Path = append(path, apiDoc.Path...)
+	apiDoc.Header = append(header, apiDoc.Header...)
+	apiDoc.Query = append(query, apiDoc.Query...)
+	apiDoc.FormData = append(formData, apiDoc.FormData...)
+}
+
+// appendGroupParam 添加参数组中的参数，接口或之前的参数组已有同名参数时忽略
+func appendGroupParam(params, declared []types.RequestParam, param types.RequestParam) []types.RequestParam {
+	for _, existing := range declared {
+		if existing.Name == param.Name {
+			return params
+		}
+	}
+	for _, existing := range params {
+		if existing.Name == param.Name {
+			return params
+		}
+	}
+	return append(params, param)
+}
+
+// catalogHasPrefix 检查目录是否为指定的目录或其子目录，前缀为空时匹配所有目录
+func catalogHasPrefix(catalog, prefix string) bool {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	catalog = strings.Trim(catalog, "/")
+	return catalog == prefix || strings.HasPrefix(catalog, prefix+"/")
+}
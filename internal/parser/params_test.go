@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+func TestParamGroups(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, _ := parseTestdata(t, "params", useResolver(resolver))
+
+			tests := []struct {
+				title  string
+				header []string
+				query  []string
+			}{
+				// 全局参数在前，@use 的参数组按顺序添加，接口声明的 size 覆盖参数组中的同名参数
+				{"用户列表", []string{"X-Request-Id", "token", "X-Tenant-Id"}, []string{"page", "size"}},
+				// 子目录匹配全局参数，@exclude 排除整个参数组
+				{"登录", []string{"X-Request-Id"}, nil},
+				// 同名参数组以配置文件为准，@exclude 排除参数组中的参数
+				{"订单列表", nil, []string{"version", "page", "size"}},
+				{"健康检查", []string{"X-Request-Id"}, nil},
+			}
+			for _, tt := range tests {
+				doc := findDoc(t, docs, tt.title)
+				if got := requestNames(doc.Header); !equalNames(got, tt.header) {
+					t.Errorf("%s header = %v, want %v", tt.title, got, tt.header)
+				}
+				if got := requestNames(doc.Query); !equalNames(got, tt.query) {
+					t.Errorf("%s query = %v, want %v", tt.title, got, tt.query)
+				}
+			}
+
+			users := findDoc(t, docs, "用户列表")
+			wantHeader := []types.RequestParam{
+				{Name: "X-Request-Id", Type: "string", Require: "false", Remark: "请求ID"},
+				{Name: "token", Type: "string", Require: "true", Remark: "登录令牌"},
+				{Name: "X-Tenant-Id", Type: "string", Require: "false", Remark: "租户ID"},
+			}
+			for i, want := range wantHeader {
+				if got := users.Header[i]; got.Name != want.Name || got.Type != want.Type || got.Require != want.Require || got.Remark != want.Remark {
+					t.Errorf("header %d = %+v, want %+v", i, got, want)
+				}
+			}
+			if page, size := users.Query[0], users.Query[1]; page.Require != "true" || size.Remark != "每页数量，最大100" {
+				t.Errorf("query = %+v", users.Query)
+			}
+			if version := findDoc(t, docs, "订单列表").Query[0]; version.Type != "int" || version.Remark != "版本号" {
+				t.Errorf("version = %+v", version)
+			}
+		})
+	}
+}
+
+func TestParamGroupDiagnostics(t *testing.T) {
+	_, diagnostics := parseTestdata(t, "params")
+
+	var messages []string
+	for _, d := range diagnostics {
+		if d.Code != diagnostic.CodeParamGroup {
+			t.Errorf("unexpected diagnostic %s", d)
+		}
+		messages = append(messages, d.Message)
+	}
+	sort.Strings(messages)
+	want := []string{
+		"@exclude Nothing 没有匹配的参数组或参数",
+		"全局参数引用的参数组 Missing 未定义，已忽略",
+		"参数组 Invalid 的位置 body 无效，应为 header、query、path 或 formData",
+		"参数组 Unknown 未定义，已忽略",
+		"参数组 Version 已在配置文件中定义，已忽略类型 Version 上的定义",
+	}
+	sort.Strings(want)
+	if !equalNames(messages, want) {
+		t.Errorf("diagnostics = %v, want %v", messages, want)
+	}
+}
+
+func TestCatalogHasPrefix(t *testing.T) {
+	tests := []struct {
+		catalog string
+		prefix  string
+		want    bool
+	}{
+		{"用户相关", "", true},
+		{"用户相关", "用户相关", true},
+		{"用户相关/认证", "用户相关", true},
+		{"/用户相关/认证/", "用户相关/", true},
+		{"用户相关管理", "用户相关", false},
+		{"订单相关", "用户相关", false},
+	}
+	for _, tt := range tests {
+		if got := catalogHasPrefix(tt.catalog, tt.prefix); got != tt.want {
+			t.Errorf("catalogHasPrefix(%q, %q) = %t, want %t", tt.catalog, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestGroupNames(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"Auth, Paging", []string{"Auth", "Paging"}},
+		{"Auth Paging", []string{"Auth", "Paging"}},
+		{" Auth,,\tPaging ", []string{"Auth", "Paging"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := groupNames(tt.value); !equalNames(got, tt.want) {
+			t.Errorf("groupNames(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
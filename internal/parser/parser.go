@@ -8,44 +8,99 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
 	"github.com/cheivin/go-runapi/pkg/types"
+	"golang.org/x/tools/go/packages"
 )
 
 // Parser 文档解析器
 type Parser struct {
-	fset           *token.FileSet
-	structInfos    map[string]types.StructInfo  // key: "package.Struct"
-	packageImports map[string]map[string]string // map[filePath]map[alias]packagePath
-	packagePaths   map[string]string            // map[packageName]packagePath
-	packageDir     string
-	extraDirs      []string
-	includeVendor  bool
+	fset            *token.FileSet
+	structInfos     map[string]types.StructInfo  // key: "package.Struct"，packages模式下为 "完整包路径.Struct"
+	namedTypes      map[string]types.NamedType   // 底层类型为基本类型的命名类型，key 与 structInfos 相同
+	packageImports  map[string]map[string]string // map[filePath]map[alias]packagePath
+	packagePaths    map[string]string            // map[packageName]packagePath
+	importPaths     map[string]string            // map[目录]包的导入路径，ast模式下按 go.mod 推算
+	filePackages    map[string]*packages.Package // map[filePath]package，仅packages模式使用
+	packageDir      string
+	extraDirs       []string
+	includeVendor   bool
+	resolver        string
+	maxDepth        int                           // 结构体最大展开深度，0表示不限制
+	typeMapping     map[string]config.TypeMapping // 自定义类型映射
+	typeAnnotations map[string]config.TypeMapping // 类型声明上 @type 注释的映射，key 与 structInfos 相同
+	marshalers      map[string]string             // 实现了 MarshalJSON 或 MarshalText 的类型对应的方法名
+	marker          string                        // 文档注释的标记关键字
+	dialect         string                        // 注释格式
+	routes          bool                          // 是否从路由注册代码中识别路由
+	strict          bool                          // 严格模式，存在警告时解析失败
+	security        config.SecurityConfig         // 认证方式和默认的认证方式
+	params          config.ParamsConfig           // 配置文件中的参数组和全局参数
+	typeGroups      map[string]typeGroup          // 类型声明上 @group 注释定义的参数组，key 为参数组名称
+	response        config.ResponseConfig         // 统一响应配置
+	envelopeKey     string                        // 统一响应结构体的key，未配置或未找到时为空
+	diag            *diagnostic.Collector         // 诊断信息收集器
+	pos             token.Pos                     // 当前解析的注释行位置，用于诊断信息定位
 }
 
 // NewParser 创建新的解析器
-func NewParser(docScanDir string, structScanDirs []string, includeVendor bool) *Parser {
+func NewParser(cfg *config.Config) *Parser {
+	// 结构体扫描目录：根目录 + 额外目录
+	structScanDirs := append([]string{cfg.Scan.Dir}, cfg.Scan.ExtraDirs...)
+
+	marker := cfg.Scan.Marker
+	if marker == "" {
+		marker = config.DefaultMarker
+	}
+
+	fset := token.NewFileSet()
 	return &Parser{
-		fset:           token.NewFileSet(),
-		structInfos:    make(map[string]types.StructInfo),
-		packageImports: make(map[string]map[string]string),
-		packagePaths:   make(map[string]string),
-		packageDir:     docScanDir,     // 文档扫描目录
-		extraDirs:      structScanDirs, // 结构体扫描目录列表
-		includeVendor:  includeVendor,
+		fset:            fset,
+		structInfos:     make(map[string]types.StructInfo),
+		namedTypes:      make(map[string]types.NamedType),
+		packageImports:  make(map[string]map[string]string),
+		packagePaths:    make(map[string]string),
+		importPaths:     make(map[string]string),
+		filePackages:    make(map[string]*packages.Package),
+		packageDir:      cfg.Scan.Scan,  // 文档扫描目录
+		extraDirs:       structScanDirs, // 结构体扫描目录列表
+		includeVendor:   cfg.Scan.IncludeVendor,
+		resolver:        cfg.Scan.Resolver,
+		maxDepth:        cfg.Scan.MaxDepth,
+		typeMapping:     cfg.Scan.TypeMapping,
+		typeAnnotations: make(map[string]config.TypeMapping),
+		marshalers:      make(map[string]string),
+		marker:          marker,
+		dialect:         cfg.Scan.Dialect,
+		routes:          cfg.Scan.Routes,
+		strict:          cfg.Scan.Strict,
+		security:        cfg.Security,
+		params:          cfg.Params,
+		typeGroups:      make(map[string]typeGroup),
+		response:        cfg.Response,
+		diag:            diagnostic.NewCollector(fset),
 	}
 }
 
 // ParseDir 解析指定目录
 func (p *Parser) ParseDir() ([]types.APIDoc, error) {
 	var apiDocs []types.APIDoc
+	p.diag.Reset()
 
 	// 首先解析所有结构体信息
 	err := p.parseStructs()
 	if err != nil {
 		return nil, fmt.Errorf("解析结构体失败: %v", err)
 	}
+	p.checkGlobalParams()
+	p.resolveEnvelope()
 
 	// 然后解析API文档
 	err = filepath.Walk(p.packageDir, func(path string, info os.FileInfo, err error) error {
@@ -65,16 +120,39 @@ func (p *Parser) ParseDir() ([]types.APIDoc, error) {
 		apiDocs = append(apiDocs, docs...)
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 从路由注册代码中补全请求方法和路径
+	if p.routes {
+		if err := p.applyRoutes(apiDocs); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range apiDocs {
+		p.checkPathParams(&apiDocs[i])
+	}
+
+	if p.strict {
+		if count := len(p.diag.Diagnostics()); count > 0 {
+			return nil, fmt.Errorf("严格模式下发现 %d 个诊断问题", count)
+		}
+	}
 
-	return apiDocs, err
+	return apiDocs, nil
 }
 
-// parseStructs 解析所有结构体定义
-func (p *Parser) parseStructs() error {
-	// 要扫描的所有目录：文档目录 + 结构体目录
+// Diagnostics 返回最近一次解析收集的诊断信息
+func (p *Parser) Diagnostics() []diagnostic.Diagnostic {
+	return p.diag.Diagnostics()
+}
+
+// scanDirs 返回要扫描的所有目录：文档目录 + 结构体目录，去重避免重复扫描同一目录
+func (p *Parser) scanDirs() []string {
 	dirsToScan := append([]string{p.packageDir}, p.extraDirs...)
 
-	// 去重，避免重复扫描同一目录
 	seen := make(map[string]bool)
 	var uniqueDirs []string
 	for _, dir := range dirsToScan {
@@ -83,17 +161,158 @@ func (p *Parser) parseStructs() error {
 			uniqueDirs = append(uniqueDirs, dir)
 		}
 	}
+	return uniqueDirs
+}
 
-	for _, dir := range uniqueDirs {
-		err := p.parseStructsInDir(dir)
+// parseStructs 解析所有结构体定义
+func (p *Parser) parseStructs() error {
+	for _, dir := range p.scanDirs() {
+		var err error
+		if p.resolver == config.ResolverPackages {
+			err = p.parseStructsInPackages(dir)
+		} else {
+			err = p.parseStructsInDir(dir)
+		}
 		if err != nil {
 			return fmt.Errorf("解析目录 %s 中的结构体失败: %v", dir, err)
 		}
 	}
 
+	// 所有结构体解析完成后，再解析字段引用的结构体
+	p.resolveFieldRefs()
+
 	return nil
 }
 
+// resolveFieldRefs 解析每个字段类型对应的结构体key
+// 字段引用的泛型结构体会在此时实例化
+func (p *Parser) resolveFieldRefs() {
+	// 切片、数组和map命名类型的底层类型中的类型解析为完整的key，如 type Users []User
+	for key, namedType := range p.namedTypes {
+		expr, err := parseTypeExpr(namedType.Underlying)
+		if err != nil || expr.Kind == kindNamed {
+			continue
+		}
+		resolved := p.resolveTypeExpr(expr, func(name string) (string, bool) {
+			if structKey, ok := p.findStructKey(namedType.Package, namedType.FilePath, name); ok {
+				return structKey, true
+			}
+			if namedKey, ok := p.findNamedType(namedType.Package, namedType.FilePath, name); ok {
+				return namedKey, true
+			}
+			return p.findKnownType(namedType.FilePath, name)
+		})
+		namedType.Underlying = resolved.String()
+		p.namedTypes[key] = namedType
+	}
+
+	// 实例化会向 structInfos 添加新的结构体，先收集现有的key
+	keys := make([]string, 0, len(p.structInfos))
+	for key := range p.structInfos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		structInfo := p.structInfos[key]
+		// 泛型结构体的字段在实例化时解析
+		if len(structInfo.TypeParams) > 0 {
+			continue
+		}
+		p.resolveFieldListRefs(structInfo.Package, structInfo.FilePath, structInfo.Fields)
+	}
+
+}
+
+// resolveFieldListRefs 解析字段列表中每个字段类型对应的结构体key和校验约束，包括匿名结构体的子字段
+// 字段类型中的命名类型替换为命名类型key，之后按key直接查找，不再依赖字段声明所在文件的导入信息
+func (p *Parser) resolveFieldListRefs(currentPackage, filePath string, fields []types.FieldInfo) {
+	for i := range fields {
+		if expr, err := parseTypeExpr(fields[i].Type); err == nil {
+			if refKey := p.fieldRef(currentPackage, filePath, expr); refKey != "" {
+				fields[i].Ref = refKey
+			}
+			fields[i].Type = p.resolveNamedTypes(currentPackage, filePath, fields[i].Type)
+		}
+		fields[i].Constraints = p.parseConstraints(currentPackage, fields[i].Tag, fields[i].Type)
+		p.resolveFieldListRefs(currentPackage, filePath, fields[i].Fields)
+	}
+}
+
+// findStructKey 在指定包的上下文中查找类型对应的结构体key
+// 带包名的类型按声明所在文件的导入信息解析，包名可以是导入别名，如 u "example.com/app/user" 中的 u.Info
+func (p *Parser) findStructKey(currentPackage, filePath, typeName string) (string, bool) {
+	if _, exists := p.structInfos[typeName]; exists {
+		return typeName, true
+	}
+
+	if !strings.Contains(typeName, ".") {
+		// 尝试在同一包内查找结构体
+		prefixedKey := currentPackage + "." + typeName
+		if _, exists := p.structInfos[prefixedKey]; exists {
+			return prefixedKey, true
+		}
+		return "", false
+	}
+
+	if filePath == "" {
+		return "", false
+	}
+	structKey, err := p.resolveStructReference(typeName, filePath)
+	if err != nil {
+		return "", false
+	}
+	_, exists := p.structInfos[structKey]
+	return structKey, exists
+}
+
+// packageImportPath 返回文件所在包的导入路径，命名类型的key以此为前缀
+// packages模式下使用加载的包路径，ast模式下按最近的 go.mod 中的模块路径推算，找不到 go.mod 时使用包名
+func (p *Parser) packageImportPath(filePath, packageName string) string {
+	if pkg, exists := p.filePackages[filepath.Clean(filePath)]; exists {
+		return pkg.PkgPath
+	}
+	if filePath == "" {
+		return packageName
+	}
+
+	dir := filepath.Dir(filePath)
+	if importPath, exists := p.importPaths[dir]; exists {
+		return importPath
+	}
+	importPath := packageName
+	if moduleDir, modulePath, ok := findModule(dir); ok {
+		if rel, err := filepath.Rel(moduleDir, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			importPath = modulePath
+			if rel != "." {
+				importPath += "/" + filepath.ToSlash(rel)
+			}
+		}
+	}
+	p.importPaths[dir] = importPath
+	return importPath
+}
+
+// findModule 从 dir 向上查找 go.mod，返回模块所在目录和模块路径
+func findModule(dir string) (string, string, bool) {
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) >= 2 && fields[0] == "module" {
+					return dir, strings.Trim(fields[1], `"`), true
+				}
+			}
+			return "", "", false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
 // parseImports 解析文件的导入信息
 func (p *Parser) parseImports(filePath string, file *ast.File) {
 	imports := make(map[string]string)
@@ -156,141 +375,243 @@ func (p *Parser) parseStructsInDir(dir string) error {
 		}
 		packageName := file.Name.Name
 
-		ast.Inspect(file, func(n ast.Node) bool {
-			genDecl, ok := n.(*ast.GenDecl)
-			if !ok || genDecl.Tok != token.TYPE {
-				return true
+		p.parseStructsInFile(path, file, packageName, packageName, relPath, p.getTypeString)
+		return nil
+	})
+}
+
+// parseStructsInFile 解析单个文件中的结构体定义
+// keyPrefix 为结构体key的前缀，typeString 用于将字段类型表达式转换为类型字符串
+func (p *Parser) parseStructsInFile(path string, file *ast.File, keyPrefix, packageName, packagePath string, typeString func(ast.Expr) string) {
+	// 命名类型的key使用包的导入路径，避免同名的包互相覆盖
+	namedPrefix := p.packageImportPath(path, packageName)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		genDecl, ok := n.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			return true
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
 			}
 
-			for _, spec := range genDecl.Specs {
-				typeSpec, ok := spec.(*ast.TypeSpec)
-				if !ok {
-					continue
+			// 使用包名+结构体名作为key
+			key := keyPrefix + "." + typeSpec.Name.Name
+			p.parseGroupAnnotation(key, genDecl, typeSpec)
+
+			// 底层类型为基本类型、已知类型、切片、数组或map的命名类型，如 type Status int、type Tags []string
+			underlying := p.resolveKnownTypes(path, typeString(typeSpec.Type))
+			_, known := p.knownType(underlying)
+			ident, isIdent := typeSpec.Type.(*ast.Ident)
+			switch typeSpec.Type.(type) {
+			case *ast.ArrayType, *ast.MapType:
+				known = true
+			}
+			if known || (isIdent && p.isBasicType(ident.Name)) {
+				namedKey := namedPrefix + "." + typeSpec.Name.Name
+				p.parseTypeAnnotation(namedKey, genDecl, typeSpec)
+				p.addNamedType(namedKey, typeSpec.Name.Name, packageName, path, underlying)
+				continue
+			}
+			p.parseTypeAnnotation(key, genDecl, typeSpec)
+
+			// 检查是否是类型别名（type alias）
+			if aliasType, isAlias := p.getTypeAlias(typeSpec.Type, typeString); isAlias {
+				// 对于类型别名，创建一个指向实际类型的引用
+				structInfo := types.StructInfo{
+					Name:        typeSpec.Name.Name,
+					Package:     packageName,
+					PackagePath: packagePath,
+					FilePath:    path,
+					// 标记这是一个类型别名
 				}
 
-				// 使用包名+结构体名作为key
-				key := packageName + "." + typeSpec.Name.Name
-
-				// 检查是否是类型别名（type alias）
-				if aliasType, isAlias := p.getTypeAlias(typeSpec.Type); isAlias {
-					// 对于类型别名，创建一个指向实际类型的引用
-					structInfo := types.StructInfo{
-						Name:        typeSpec.Name.Name,
-						Package:     packageName,
-						PackagePath: relPath,
-						// 标记这是一个类型别名
+				// 解析实际类型，泛型实例需要等所有结构体解析完成后再实例化
+				actualTypeKey := aliasType
+				if !strings.Contains(aliasType, "[") {
+					if resolvedKey, err := p.resolveStructReference(aliasType, path); err == nil {
+						actualTypeKey = resolvedKey
 					}
+				}
 
-					// 解析实际类型
-					actualTypeKey, err := p.resolveStructReference(aliasType, path)
-					if err != nil {
-						// 如果解析失败，尝试直接查找
-						actualTypeKey = aliasType
+				// 如果找到了实际类型，将实际类型的字段复制过来
+				if actualStructInfo, exists := p.structInfos[actualTypeKey]; exists {
+					// 复制实际类型的字段
+					structInfo.Fields = make([]types.FieldInfo, len(actualStructInfo.Fields))
+					copy(structInfo.Fields, actualStructInfo.Fields)
+				} else {
+					// 如果找不到实际类型，添加一个占位符，稍后在 buildStructFields 中会尝试解析
+					aliasName := embeddedName(typeSpec.Type)
+					structInfo.Fields = []types.FieldInfo{
+						{
+							Name:     aliasName,
+							GoName:   aliasName,
+							Type:     aliasType,
+							Embedded: true,
+							Remark:   "类型别名指向: " + aliasType,
+						},
 					}
+				}
 
-					// 如果找到了实际类型，将实际类型的字段复制过来
-					if actualStructInfo, exists := p.structInfos[actualTypeKey]; exists {
-						// 复制实际类型的字段
-						structInfo.Fields = make([]types.FieldInfo, len(actualStructInfo.Fields))
-						copy(structInfo.Fields, actualStructInfo.Fields)
-					} else {
-						// 如果找不到实际类型，添加一个占位符，稍后在 deepParseStruct 中会尝试解析
-						structInfo.Fields = []types.FieldInfo{
-							{
-								Name:     aliasType,
-								Type:     aliasType,
-								Required: false,
-								Remark:   "类型别名指向: " + aliasType,
-							},
-						}
-					}
+				p.structInfos[key] = structInfo
+				continue
+			}
 
-					p.structInfos[key] = structInfo
-					continue
-				}
+			// 处理普通结构体
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
 
-				// 处理普通结构体
-				structType, ok := typeSpec.Type.(*ast.StructType)
-				if !ok {
-					continue
-				}
+			structInfo := types.StructInfo{
+				Name:        typeSpec.Name.Name,
+				Package:     packageName,
+				PackagePath: packagePath,
+				FilePath:    path,
+			}
 
-				structInfo := types.StructInfo{
-					Name:        typeSpec.Name.Name,
-					Package:     packageName,
-					PackagePath: relPath,
+			// 泛型结构体的类型参数
+			if typeSpec.TypeParams != nil {
+				for _, param := range typeSpec.TypeParams.List {
+					for _, name := range param.Names {
+						structInfo.TypeParams = append(structInfo.TypeParams, name.Name)
+					}
 				}
+			}
+
+			structInfo.Fields = p.parseFieldList(structType.Fields, typeString)
+
+			p.structInfos[key] = structInfo
+		}
 
-				for _, field := range structType.Fields.List {
-					if len(field.Names) == 0 {
-						// 嵌入字段（匿名字段）
-						fieldType := p.getTypeString(field.Type)
-						fieldInfo := types.FieldInfo{
-							Name:     fieldType,
-							Type:     fieldType,
-							Required: true, // 嵌入字段默认必传
-							Remark:   "嵌入字段",
-						}
-
-						// 提取字段注释
-						if field.Comment != nil {
-							for _, comment := range field.Comment.List {
-								fieldInfo.Remark = strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
-							}
-						}
-
-						structInfo.Fields = append(structInfo.Fields, fieldInfo)
-					} else {
-						// 普通字段
-						for _, name := range field.Names {
-							// 跳过未导出的字段（小写字母开头）
-							if !isExported(name.Name) {
-								continue
-							}
-
-							fieldInfo := types.FieldInfo{
-								Name: name.Name,
-								Type: p.getTypeString(field.Type),
-							}
-
-							// 提取JSON tag
-							if field.Tag != nil {
-								tag := strings.Trim(field.Tag.Value, "`")
-								if jsonName, omitempty, ok := p.extractJSONTagInfo(tag); ok {
-									if jsonName == "-" {
-										continue // 跳过不序列化的字段
-									}
-									fieldInfo.Name = jsonName
-									fieldInfo.Required = !omitempty // 有omitempty则为非必传，否则为必传
-								}
-							}
-
-							// 提取字段注释
-							if field.Comment != nil {
-								for _, comment := range field.Comment.List {
-									fieldInfo.Remark = strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
-								}
-							}
-
-							structInfo.Fields = append(structInfo.Fields, fieldInfo)
-						}
+		return true
+	})
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			// 包级别的常量，作为命名类型的可选值
+			if decl.Tok == token.CONST {
+				p.parseConstDecl(path, decl, namedPrefix, typeString)
+			}
+		case *ast.FuncDecl:
+			// 自定义序列化方法，JSON中的类型以方法的输出为准
+			p.parseMarshalMethod(decl, keyPrefix)
+			if namedPrefix != keyPrefix {
+				p.parseMarshalMethod(decl, namedPrefix)
+			}
+		}
+	}
+}
+
+// parseFieldList 解析结构体的字段列表，匿名结构体字段的子字段保存在 FieldInfo.Fields 中
+// 嵌入字段使用类型名作为Go字段名，是否提升子字段在构建树形结构时按 encoding/json 的规则决定
+func (p *Parser) parseFieldList(fieldList *ast.FieldList, typeString func(ast.Expr) string) []types.FieldInfo {
+	var fields []types.FieldInfo
+
+	for _, field := range fieldList.List {
+		names := field.Names
+		embedded := len(names) == 0
+		if embedded {
+			names = []*ast.Ident{ast.NewIdent(embeddedName(field.Type))}
+		}
+
+		for _, name := range names {
+			// 跳过未导出的字段（小写字母开头），未导出的嵌入结构体的导出字段仍会被提升
+			if !embedded && !isExported(name.Name) {
+				continue
+			}
+
+			fieldInfo := types.FieldInfo{
+				Name:     name.Name,
+				GoName:   name.Name,
+				Type:     typeString(field.Type),
+				Embedded: embedded,
+			}
+
+			// 提取JSON tag
+			if field.Tag != nil {
+				tag := strings.Trim(field.Tag.Value, "`")
+				fieldInfo.Tag = tag
+				if jsonName, omitempty, ok := p.extractJSONTagInfo(tag); ok {
+					switch {
+					case jsonName == "-":
+						// 不序列化的字段保留给参数绑定使用，如 ID int64 `json:"-" uri:"id"`
+						fieldInfo.JSONSkip = true
+					case jsonName != "":
+						fieldInfo.Name = jsonName
 					}
+					fieldInfo.Required = !omitempty // 有omitempty则为非必传，否则为必传
+					fieldInfo.JSONString = hasJSONOption(tag, "string")
 				}
 
-				p.structInfos[key] = structInfo
+				// 提取示例值，校验约束在所有命名类型解析完成后提取
+				fieldInfo.Example = reflect.StructTag(tag).Get("example")
 			}
 
-			return true
-		})
+			// 提取字段注释
+			if field.Comment != nil {
+				for _, comment := range field.Comment.List {
+					fieldInfo.Remark = strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+				}
+			}
+			fieldInfo.Remark, fieldInfo.Deprecated, fieldInfo.DeprecatedNote = fieldDeprecation(field, fieldInfo.Remark)
 
-		return nil
-	})
+			// 匿名结构体字段，如 Extra struct{...} 或 Items []struct{...}
+			if structType := inlineStructType(field.Type); structType != nil {
+				fieldInfo.Fields = p.parseFieldList(structType.Fields, typeString)
+			}
+
+			fields = append(fields, fieldInfo)
+		}
+	}
+
+	return fields
 }
 
-// parseFile 解析单个文件
-func (p *Parser) parseFile(filePath string) ([]types.APIDoc, error) {
-	var apiDocs []types.APIDoc
+// embeddedName 返回嵌入字段的字段名，即去除指针、包名和类型实参后的类型名，如 *model.Page[T] 为 Page
+func embeddedName(expr ast.Expr) string {
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.SelectorExpr:
+			return t.Sel.Name
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
 
+// inlineStructType 返回字段类型中（去除指针、切片、数组、map后）的匿名结构体
+func inlineStructType(expr ast.Expr) *ast.StructType {
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ArrayType:
+			expr = t.Elt
+		case *ast.MapType:
+			expr = t.Value
+		case *ast.StructType:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// parseFile 解析单个文件，文档注释可以写在函数、变量声明、函数字面量和路由表元素上
+func (p *Parser) parseFile(filePath string) ([]types.APIDoc, error) {
 	src, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
@@ -301,72 +622,151 @@ func (p *Parser) parseFile(filePath string) ([]types.APIDoc, error) {
 		return nil, err
 	}
 
-	ast.Inspect(file, func(n ast.Node) bool {
-		funcDecl, ok := n.(*ast.FuncDecl)
-		if !ok {
-			return true
-		}
-
-		if funcDecl.Doc == nil {
-			return true
-		}
-
-		// 检查是否包含 runapi 标记
-		hasRunAPI := false
-		for _, comment := range funcDecl.Doc.List {
-			if strings.TrimSpace(comment.Text) == "// runapi" {
-				hasRunAPI = true
-				break
+	c := newAnnotationCollector(p, filePath, ast.NewCommentMap(p.fset, file, file.Comments))
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			c.add(decl.Doc, decl.Name.Name, decl.Name.Name, nil)
+			if decl.Body != nil {
+				c.nameClosures(decl.Body, decl.Name.Name)
+				c.walk(decl.Body, decl.Name.Name)
 			}
+		case *ast.GenDecl:
+			c.genDecl(decl)
 		}
+	}
 
-		if !hasRunAPI {
-			return true
-		}
-
-		apiDoc, err := p.parseFuncDoc(funcDecl.Doc, filePath)
-		if err != nil {
-			fmt.Printf("解析函数 %s 的文档失败: %v\n", funcDecl.Name.Name, err)
-			return true
-		}
-
-		// 添加位置信息用于错误定位
-		apiDoc.FilePath = filePath
-		apiDoc.FunctionName = funcDecl.Name.Name
+	return c.apiDocs, nil
+}
 
-		apiDocs = append(apiDocs, *apiDoc)
-		return true
-	})
+// parseAnnotation 解析文档注释，带 runapi 标记的按 runapi 注释解析，swag 格式下没有标记但带 @Router 的按 swag 注释解析
+func (p *Parser) parseAnnotation(doc *ast.CommentGroup, filePath, name string) (*types.APIDoc, bool) {
+	var (
+		apiDoc *types.APIDoc
+		err    error
+	)
+	switch {
+	case p.hasMarker(doc):
+		apiDoc, err = p.parseFuncDoc(doc, filePath)
+	case p.dialect == config.DialectSwag && isSwagDoc(doc):
+		apiDoc, err = p.parseSwagDoc(doc, filePath)
+	default:
+		p.checkMissingMarker(doc, name)
+		return nil, false
+	}
+	if err != nil {
+		p.diag.Errorf(doc.Pos(), diagnostic.CodeParseFailed, "解析函数 %s 的文档失败: %v", name, err)
+		return nil, false
+	}
+	return apiDoc, true
+}
 
-	return apiDocs, nil
+// docTags 文档注释支持的标签
+var docTags = map[string]bool{
+	"@catalog":            true,
+	"@title":              true,
+	"@description":        true,
+	"@remark":             true,
+	"@method":             true,
+	"@router":             true,
+	"@url":                true,
+	"@param":              true,
+	"@response":           true,
+	"@response_body":      true,
+	"@query":              true,
+	"@form":               true,
+	"@header":             true,
+	"@uri":                true,
+	"@failure":            true,
+	"@response_fail_body": true,
+	"@body":               true,
+	"@deprecated":         true,
+	"@since":              true,
+	"@sunset":             true,
+	"@security":           true,
+	"@use":                true,
+	"@exclude":            true,
+	"@data":               true,
+	"@raw":                true,
 }
 
 // parseFuncDoc 解析函数文档注释
 func (p *Parser) parseFuncDoc(doc *ast.CommentGroup, filePath string) (*types.APIDoc, error) {
 	apiDoc := &types.APIDoc{}
 
-	for _, comment := range doc.List {
-		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+	// 多行文本：@description 和 @remark 之后不以 @ 开头的行作为续行，保留缩进和空行以支持Markdown
+	// runapi 标记之后、不属于任何标签的文本追加到描述中
+	var block *string
+	inFence := false
+	afterMarker := false
+	var security, use, exclude []string
+	securityDeclared := false
+	// 声明了 @raw 的接口不使用统一响应结构，@raw 可以写在响应标签之后
+	wrap := !isRawDoc(doc)
+
+	for _, docLine := range commentLines(doc) {
+		line := docLine.text
+		text := strings.TrimSpace(line)
+		p.pos = docLine.pos
+
+		if p.isMarker(text) {
+			afterMarker = true
+			block = nil
+			continue
+		}
 
-		if text == "runapi" {
+		// 代码块中的内容即使以 @ 开头也作为续行
+		if block != nil && (inFence || !strings.HasPrefix(text, "@")) {
+			if strings.HasPrefix(text, "```") {
+				inFence = !inFence
+			}
+			*block = appendLine(*block, line)
 			continue
 		}
+		block, inFence = nil, false
 
-		if strings.HasPrefix(text, "@remark") {
-			apiDoc.Remark = strings.TrimSpace(strings.TrimPrefix(text, "@remark"))
+		if !strings.HasPrefix(text, "@") {
+			if afterMarker && text != "" {
+				apiDoc.Description = appendLine(apiDoc.Description, line)
+				block = &apiDoc.Description
+			}
 			continue
 		}
 
-		// 使用空格分割，但保留第一个词作为key
-		spaceIndex := strings.Index(text, " ")
-		if spaceIndex == -1 {
+		// 多行标签的值可以为空，内容写在后续行中；重复的标签追加为新的一行
+		if tag, value, _ := strings.Cut(text, " "); tag == "@description" || tag == "@remark" {
+			block = &apiDoc.Description
+			if tag == "@remark" {
+				block = &apiDoc.Remark
+			}
+			if value = strings.TrimSpace(value); value != "" {
+				*block = appendLine(*block, value)
+			}
 			continue
 		}
 
-		key := text[:spaceIndex]
-		value := strings.TrimSpace(text[spaceIndex:])
+		// 使用空格分割，但保留第一个词作为key
+		key, value, _ := strings.Cut(text, " ")
+		value = strings.TrimSpace(value)
 
+		if !docTags[key] {
+			// 与 swag 注释共存时，swag 的 @Summary、@Param 等标签直接忽略
+			if !isSwagTag(key) {
+				p.warnf(diagnostic.CodeUnknownTag, "未知的注释标签 %s，已忽略", key)
+			}
+			continue
+		}
+		// @deprecated 的废弃说明可以省略
+		if key == "@deprecated" {
+			apiDoc.Deprecated = true
+			apiDoc.DeprecatedNote = value
+			continue
+		}
+		if key == "@raw" {
+			continue
+		}
 		if value == "" {
+			p.warnf(diagnostic.CodeEmptyTag, "注释标签 %s 缺少值，已忽略", key)
 			continue
 		}
 
@@ -375,9 +775,10 @@ func (p *Parser) parseFuncDoc(doc *ast.CommentGroup, filePath string) (*types.AP
 			apiDoc.Catalog = value
 		case "@title":
 			apiDoc.Title = value
-		case "@description":
-			apiDoc.Description = value
 		case "@method":
+			if routeMethods[strings.ToUpper(value)] == "" {
+				p.warnf(diagnostic.CodeInvalidMethod, "无效的请求方法 %s", value)
+			}
 			apiDoc.Method = value
 		case "@router":
 			apiDoc.Router = value
@@ -385,7 +786,9 @@ func (p *Parser) parseFuncDoc(doc *ast.CommentGroup, filePath string) (*types.AP
 			apiDoc.URL = value
 		case "@param":
 			paramParts := strings.Fields(value)
-			if len(paramParts) >= 4 {
+			if len(paramParts) < 4 {
+				p.warnf(diagnostic.CodeInvalidParam, "@param 格式错误: %s，应为 @param 名称 位置 类型 是否必填 [说明]", value)
+			} else {
 				paramName := paramParts[0]
 				paramLocation := paramParts[1]
 				paramType := paramParts[2]
@@ -395,8 +798,8 @@ func (p *Parser) parseFuncDoc(doc *ast.CommentGroup, filePath string) (*types.AP
 					paramRemark = strings.Join(paramParts[4:], " ")
 				}
 
-				// 应用类型映射
-				mappedType := p.mapGoTypeToRequestType(paramType)
+				// 应用类型映射，命名类型按文件的导入信息解析
+				mappedType := p.mapGoTypeToRequestType(p.resolveNamedTypes("", filePath, paramType))
 
 				param := types.RequestParam{
 					Name:    paramName,
@@ -406,17 +809,22 @@ func (p *Parser) parseFuncDoc(doc *ast.CommentGroup, filePath string) (*types.AP
 				}
 
 				switch paramLocation {
+				case "path":
+					apiDoc.Path = append(apiDoc.Path, param)
 				case "header":
 					apiDoc.Header = append(apiDoc.Header, param)
 				case "query":
 					apiDoc.Query = append(apiDoc.Query, param)
 				case "formData":
 					apiDoc.FormData = append(apiDoc.FormData, param)
+				default:
+					p.warnf(diagnostic.CodeInvalidParam, "参数 %s 的位置 %s 无效，应为 path、header、query 或 formData", paramName, paramLocation)
 				}
 			}
 		case "@response":
 			responseParts := strings.Fields(value)
-			if len(responseParts) >= 3 {
+			// 结构体格式中的泛型实参或字段覆盖可能包含空格，如 Pair[K, V]
+			if len(responseParts) >= 3 && !strings.ContainsAny(responseParts[0], "[{") {
 				paramName := responseParts[0]
 				paramLocation := responseParts[1]
 				paramType := responseParts[2]
@@ -438,81 +846,198 @@ func (p *Parser) parseFuncDoc(doc *ast.CommentGroup, filePath string) (*types.AP
 					apiDoc.ResponseHeader = append(apiDoc.ResponseHeader, param)
 				} else if paramLocation == "body" {
 					apiDoc.ResponseBody = append(apiDoc.ResponseBody, param)
+					apiDoc.ResponseSchema = mergeSchema(apiDoc.ResponseSchema, &types.Schema{
+						Type:     "object",
+						Children: []*types.Schema{{Name: paramName, Type: mappedType, GoType: paramType, Remark: paramRemark}},
+					})
+				} else {
+					p.warnf(diagnostic.CodeInvalidResponse, "响应参数 %s 的位置 %s 无效，应为 header 或 body", paramName, paramLocation)
 				}
 			} else {
 				// 处理结构体格式的响应
-				responseValue := value
-				if strings.Contains(responseValue, "{") && strings.HasSuffix(responseValue, "}") {
-					// 解析嵌套响应格式
-					nestedParams, err := p.parseNestedResponse(responseValue, filePath)
-					if err == nil && len(nestedParams) > 0 {
-						apiDoc.ResponseBody = append(apiDoc.ResponseBody, nestedParams...)
-					}
-				} else if _, exists := p.structInfos[responseValue]; exists {
-					nestedParams := p.deepParseStruct(responseValue, "")
-					apiDoc.ResponseBody = append(apiDoc.ResponseBody, nestedParams...)
-				}
+				p.parseResponseBody(apiDoc, value, filePath, wrap)
 			}
 		case "@response_body":
-			responseValue := value
-			if strings.Contains(responseValue, "{") && strings.HasSuffix(responseValue, "}") {
-				// 解析嵌套响应格式
-				nestedParams, err := p.parseNestedResponse(responseValue, filePath)
-				if err == nil && len(nestedParams) > 0 {
-					apiDoc.ResponseBody = append(apiDoc.ResponseBody, nestedParams...)
-				}
-			} else {
-				// 尝试解析带包名的结构体引用
-				structKey, err := p.resolveStructReference(responseValue, filePath)
-				if err != nil {
-					// 如果解析失败，尝试直接查找
-					structKey = responseValue
-				}
-
-				if _, exists := p.structInfos[structKey]; exists {
-					nestedParams := p.deepParseStruct(structKey, "")
-					apiDoc.ResponseBody = append(apiDoc.ResponseBody, nestedParams...)
-				}
+			p.parseResponseBody(apiDoc, value, filePath, wrap)
+		case "@data":
+			if p.envelopeKey == "" {
+				p.warnf(diagnostic.CodeInvalidResponse, "未配置统一响应结构体 response.envelope，@data 按 @response_body 解析")
 			}
+			p.parseResponseBody(apiDoc, value, filePath, true)
+		case "@query":
+			p.parseBindingParams(apiDoc, "query", value, filePath)
+		case "@form":
+			p.parseBindingParams(apiDoc, "formData", value, filePath)
+		case "@header":
+			p.parseBindingParams(apiDoc, "header", value, filePath)
+		case "@uri":
+			p.parseBindingParams(apiDoc, "path", value, filePath)
+		case "@failure":
+			p.parseFailure(apiDoc, value, filePath, wrap)
+		case "@response_fail_body":
+			// 未指定状态码的失败响应
+			p.parseFailure(apiDoc, "0 "+value, filePath, wrap)
 		case "@body":
-			bodyType := value
-			// 尝试解析带包名的结构体引用
-			structKey, err := p.resolveStructReference(bodyType, filePath)
-			if err != nil {
-				// 如果解析失败，尝试直接查找
-				structKey = bodyType
-			}
+			p.parseRequestBody(apiDoc, value, filePath)
+		case "@since":
+			apiDoc.Since = value
+		case "@sunset":
+			p.parseSunset(apiDoc, value)
+		case "@security":
+			securityDeclared = true
+			security = p.appendSecurity(security, value)
+		case "@use":
+			use = p.appendUse(use, value)
+		case "@exclude":
+			exclude = p.appendExclude(exclude, value)
+		}
+	}
+	apiDoc.Security = p.resolveSecurity(security, securityDeclared)
+	p.applyParamGroups(apiDoc, use, exclude)
 
-			if _, exists := p.structInfos[structKey]; exists {
-				nestedParams := p.deepParseStruct(structKey, "")
-				// 转换为types.RequestParam并应用请求类型映射
-				for _, field := range nestedParams {
-					var requireStr string
-					if field.Required {
-						requireStr = "true"
-					} else {
-						requireStr = "false"
-					}
+	apiDoc.Description = trimBlock(apiDoc.Description)
+	apiDoc.Remark = trimBlock(apiDoc.Remark)
 
-					// 对于请求体参数，需要从Go类型映射到请求类型
-					// 使用辅助方法获取原始Go类型
-					goType := p.getFieldOriginalGoType(structKey, field.Name)
-					requestParam := types.RequestParam{
-						Name:    field.Name,
-						Type:    p.mapGoTypeToRequestType(goType),
-						Require: requireStr,
-						Remark:  field.Remark,
-					}
-					apiDoc.Body = append(apiDoc.Body, requestParam)
-				}
-			} else {
-				fmt.Printf("警告: 未找到结构体 %s (原始: %s)\n", structKey, bodyType)
-				fmt.Printf("可用的结构体: %v\n", p.getAvailableStructs())
+	return apiDoc, nil
+}
+
+// parseSunset 解析计划下线日期，格式为 2006-01-02
+func (p *Parser) parseSunset(apiDoc *types.APIDoc, value string) {
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		p.warnf(diagnostic.CodeInvalidSunset, "计划下线日期 %s 格式错误，应为 2006-01-02", value)
+		return
+	}
+	apiDoc.Sunset = value
+}
+
+// appendLine 向多行文本追加一行
+func appendLine(block, line string) string {
+	if block == "" {
+		return line
+	}
+	return block + "\n" + line
+}
+
+// trimBlock 去掉多行文本首尾的空行，保留第一行的缩进
+func trimBlock(block string) string {
+	return strings.TrimLeft(strings.TrimRight(block, " \t\n"), "\n")
+}
+
+// checkPathParams 校验路径参数与路由中的占位符是否一致，支持 {id}、:id 和 *path 写法
+func (p *Parser) checkPathParams(apiDoc *types.APIDoc) {
+	router := apiDoc.URL
+	if router == "" {
+		router = apiDoc.Router
+	}
+
+	placeholders := pathPlaceholders(router)
+	declared := make(map[string]bool)
+	for _, param := range apiDoc.Path {
+		declared[param.Name] = true
+		if !containsString(placeholders, param.Name) {
+			p.docWarnf(*apiDoc, diagnostic.CodePathParam, "接口 %s 的路径参数 %s 在路由 %s 中没有对应的占位符", apiDoc.Title, param.Name, router)
+		}
+	}
+	for _, name := range placeholders {
+		if !declared[name] {
+			p.docWarnf(*apiDoc, diagnostic.CodePathParam, "接口 %s 的路由 %s 中的占位符 %s 缺少 @param %s path 声明", apiDoc.Title, router, name, name)
+		}
+	}
+}
+
+// pathPlaceholders 提取路由中的路径参数占位符，忽略 {{host}} 等ShowDoc变量
+func pathPlaceholders(router string) []string {
+	var placeholders []string
+	for _, segment := range strings.Split(router, "/") {
+		switch {
+		case strings.HasPrefix(segment, "{{"):
+			continue
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			// Go 1.22 路由的通配符写法 {path...}
+			name := strings.TrimSuffix(segment[1:len(segment)-1], "...")
+			placeholders = append(placeholders, name)
+		case (strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*")) && len(segment) > 1:
+			// gin/echo 风格的 :id 和 *filepath
+			placeholders = append(placeholders, segment[1:])
+		}
+	}
+	return placeholders
+}
+
+// containsString 检查字符串切片中是否包含指定字符串
+func containsString(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRequestBody 解析请求体结构体
+func (p *Parser) parseRequestBody(apiDoc *types.APIDoc, bodyType string, filePath string) {
+	// 尝试解析带包名的结构体引用
+	structKey, err := p.resolveStructReference(bodyType, filePath)
+	if err != nil {
+		// 如果解析失败，尝试直接查找
+		structKey = bodyType
+	}
+
+	if _, exists := p.structInfos[structKey]; exists {
+		apiDoc.BodySchema = p.buildRequestSchema(structKey)
+		apiDoc.Body = append(apiDoc.Body, p.requestParams(apiDoc.BodySchema)...)
+	} else {
+		p.warnStructNotFound(bodyType)
+	}
+}
+
+// parseResponseBody 解析结构体格式的响应体，wrap 为 true 时使用统一响应结构包装
+func (p *Parser) parseResponseBody(apiDoc *types.APIDoc, responseValue string, filePath string, wrap bool) {
+	schema, err := p.buildBodySchema(responseValue, filePath, wrap)
+	if err != nil {
+		p.warnSchemaError(err)
+		return
+	}
+	apiDoc.ResponseSchema = mergeSchema(apiDoc.ResponseSchema, schema)
+	apiDoc.ResponseBody = append(apiDoc.ResponseBody, p.responseParams(schema)...)
+}
+
+// parseFailure 解析失败响应，格式为 "<状态码> <结构体> [描述]"，如 "400 response.Error 参数错误"
+// 结构体同样支持 Response{data=UserInfo} 格式的字段覆盖，无法解析的结构体只记录状态码和描述
+// wrap 为 true 时使用统一响应结构包装，没有结构体的失败响应使用统一响应结构本身
+func (p *Parser) parseFailure(apiDoc *types.APIDoc, value string, filePath string, wrap bool) {
+	statusStr, rest := splitLeadingType(value)
+	status, err := strconv.Atoi(statusStr)
+	if err != nil {
+		p.warnf(diagnostic.CodeInvalidFailure, "失败响应状态码 %s 无效", statusStr)
+		return
+	}
+
+	failure := types.Failure{Status: status}
+	responseValue, description := splitLeadingType(rest)
+	failure.Description = description
+
+	if responseValue != "" {
+		schema, err := p.buildBodySchema(responseValue, filePath, wrap)
+		if err != nil {
+			// 不是结构体时作为描述的一部分，看起来像类型名时给出警告
+			if c := responseValue[0]; c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' {
+				p.warnSchemaError(err)
 			}
+			failure.Description = rest
+		} else {
+			failure.Schema = schema
+			failure.Body = p.responseParams(schema)
 		}
 	}
 
-	return apiDoc, nil
+	// 没有响应结构的失败响应使用统一响应结构
+	if failure.Schema == nil && wrap && p.envelopeKey != "" {
+		failure.Schema = p.buildStructSchema(p.envelopeKey)
+		failure.Body = p.responseParams(failure.Schema)
+	}
+
+	apiDoc.Failures = append(apiDoc.Failures, failure)
 }
 
 // parseParam 解析参数行
@@ -548,37 +1073,53 @@ type JSONTagInfo struct {
 
 // extractJSONTagInfo 提取JSON tag的完整信息
 func (p *Parser) extractJSONTagInfo(tagStr string) (string, bool, bool) {
-	parts := strings.Split(tagStr, " ")
-	if len(parts) == 0 {
+	jsonTag, ok := reflect.StructTag(tagStr).Lookup("json")
+	if !ok {
 		return "", false, false
 	}
+	if jsonTag == "-" {
+		return "-", false, true // 跳过不序列化的字段
+	}
 
-	// 遍历所有 tag 部分，查找 json: 开头的部分
-	for _, part := range parts {
-		if strings.HasPrefix(part, "json:") {
-			jsonTag := strings.Trim(part[5:], "\"")
-			if jsonTag == "-" {
-				return "-", false, true // 跳过不序列化的字段
-			}
-
-			var fieldName string
-			var omitempty bool
+	// 解析逗号分隔的选项
+	fieldName, options, _ := strings.Cut(jsonTag, ",")
+	omitempty := strings.Contains(options, "omitempty")
 
-			// 解析逗号分隔的选项
-			if commaIndex := strings.Index(jsonTag, ","); commaIndex != -1 {
-				fieldName = jsonTag[:commaIndex]
-				options := jsonTag[commaIndex+1:]
-				omitempty = strings.Contains(options, "omitempty")
-			} else {
-				fieldName = jsonTag
-				omitempty = false
-			}
+	return fieldName, omitempty, true
+}
 
-			return fieldName, omitempty, true
+// hasJSONOption 检查JSON标签是否包含指定的选项，如 json:"id,string" 的 string
+func hasJSONOption(tagStr, option string) bool {
+	jsonTag, ok := reflect.StructTag(tagStr).Lookup("json")
+	if !ok {
+		return false
+	}
+	_, options, _ := strings.Cut(jsonTag, ",")
+	for _, value := range strings.Split(options, ",") {
+		if strings.TrimSpace(value) == option {
+			return true
 		}
 	}
+	return false
+}
 
-	return "", false, false
+// hasRequiredRule 检查 binding 或 validate 标签是否包含 required 规则
+// 返回值 ok 表示是否存在校验标签
+func hasRequiredRule(tagStr string) (required bool, ok bool) {
+	structTag := reflect.StructTag(tagStr)
+	for _, key := range []string{"binding", "validate"} {
+		rules, exists := structTag.Lookup(key)
+		if !exists {
+			continue
+		}
+		ok = true
+		for _, rule := range strings.Split(rules, ",") {
+			if strings.TrimSpace(rule) == "required" {
+				required = true
+			}
+		}
+	}
+	return required, ok
 }
 
 // isExported 检查标识符是否为导出的（大写字母开头）
@@ -591,21 +1132,27 @@ func isExported(name string) bool {
 
 // getTypeAlias 检测类型别名并返回别名指向的类型
 // 如果是类型别名（type X = Y），返回 Y；否则返回空字符串
-func (p *Parser) getTypeAlias(expr ast.Expr) (string, bool) {
+func (p *Parser) getTypeAlias(expr ast.Expr, typeString func(ast.Expr) string) (string, bool) {
 	// 检查是否是标识符（如 type DeleteRequest = IdRequest）
 	if ident, ok := expr.(*ast.Ident); ok {
 		// 如果标识符不是基本类型，可能是类型别名
 		if !p.isBasicType(ident.Name) {
-			return ident.Name, true
+			return typeString(ident), true
 		}
 	}
 
 	// 检查是否是选择器表达式（如 type DeleteRequest = common2.IdRequest）
 	if selector, ok := expr.(*ast.SelectorExpr); ok {
-		aliasType := p.getTypeString(selector)
+		aliasType := typeString(selector)
 		return aliasType, true
 	}
 
+	// 检查是否是泛型实例（如 type UserPage = Page[User]）
+	switch expr.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return typeString(expr), true
+	}
+
 	// 检查是否是结构体类型
 	if _, ok := expr.(*ast.StructType); ok {
 		return "", false
@@ -645,16 +1192,66 @@ func (p *Parser) getTypeString(expr ast.Expr) string {
 	case *ast.StarExpr:
 		return "*" + p.getTypeString(t.X)
 	case *ast.ArrayType:
+		if t.Len != nil {
+			return "[" + p.getArrayLen(t.Len) + "]" + p.getTypeString(t.Elt)
+		}
+		return "[]" + p.getTypeString(t.Elt)
+	case *ast.Ellipsis:
+		// 可变参数按切片处理
 		return "[]" + p.getTypeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + p.getTypeString(t.Key) + "]" + p.getTypeString(t.Value)
 	case *ast.SelectorExpr:
 		return p.getTypeString(t.X) + "." + t.Sel.Name
+	case *ast.IndexExpr:
+		return p.getTypeString(t.X) + "[" + p.getTypeString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			args[i] = p.getTypeString(index)
+		}
+		return p.getTypeString(t.X) + "[" + strings.Join(args, ",") + "]"
+	case *ast.StructType:
+		// 匿名结构体的字段单独解析，类型字符串中不展开
+		return "struct{}"
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.ChanType:
+		return "chan " + p.getTypeString(t.Value)
+	case *ast.FuncType:
+		return "func()"
+	case *ast.ParenExpr:
+		return p.getTypeString(t.X)
 	default:
 		return fmt.Sprintf("%T", expr)
 	}
 }
 
+// getArrayLen 获取数组长度表达式的字符串，如 [3]int 或 [Size]int
+func (p *Parser) getArrayLen(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return p.getTypeString(t)
+	default:
+		return "N"
+	}
+}
+
 // resolveStructReference 解析结构体引用，支持包名
 func (p *Parser) resolveStructReference(structRef string, filePath string) (string, error) {
+	// 泛型结构体引用，如 Result[user.Info]
+	if strings.Contains(structRef, "[") {
+		return p.resolveGenericReference(structRef, filePath)
+	}
+
+	if p.resolver == config.ResolverPackages {
+		return p.resolvePackageReference(structRef, filePath)
+	}
+
 	// 如果没有包名前缀，尝试在当前包中查找
 	if !strings.Contains(structRef, ".") {
 		// 获取当前文件的包名
@@ -748,318 +1345,6 @@ func (p *Parser) removeModulePrefix(importPath string) string {
 	return importPath
 }
 
-// getAvailableStructs 获取所有可用的结构体，用于调试
-func (p *Parser) getAvailableStructs() []string {
-	var structs []string
-	for key := range p.structInfos {
-		structs = append(structs, key)
-	}
-	return structs
-}
-
-// parseNestedResponse 解析嵌套响应格式，如 Response{data=UserInfo} 或 Response{result=user.Info}
-func (p *Parser) parseNestedResponse(responseValue string, filePath string) ([]types.ResponseParam, error) {
-	// 检查是否是 StructName{...} 格式
-	if !strings.Contains(responseValue, "{") || !strings.HasSuffix(responseValue, "}") {
-		return nil, fmt.Errorf("不是有效的嵌套响应格式")
-	}
-
-	// 提取结构体名称和内部内容
-	leftBrace := strings.Index(responseValue, "{")
-	baseStructName := strings.TrimSpace(responseValue[:leftBrace])
-	innerContent := strings.TrimPrefix(responseValue, baseStructName+"{")
-	innerContent = strings.TrimSuffix(innerContent, "}")
-
-	var params []types.ResponseParam
-
-	// 解析基础结构体名称（可能包含包名）
-	baseStructKey, err := p.resolveStructReference(baseStructName, filePath)
-	if err != nil {
-		// 如果解析失败，尝试直接查找
-		baseStructKey = baseStructName
-	}
-
-	// 首先添加基础结构体的字段
-	if structInfo, exists := p.structInfos[baseStructKey]; exists {
-		for _, field := range structInfo.Fields {
-			// 跳过被标记为 - 的字段（不序列化的字段）
-			if field.Name == "-" {
-				continue
-			}
-			// 将 FieldInfo 转换为 ResponseParam
-			params = append(params, types.ResponseParam{
-				Name:     field.Name,
-				Type:     p.mapGoTypeToResponseType(field.Type),
-				Required: field.Required,
-				Remark:   field.Remark,
-			})
-		}
-	}
-
-	// 如果没有内部覆盖内容，直接返回基础结构体字段
-	if innerContent == "" {
-		return params, nil
-	}
-
-	// 解析字段覆盖，如 "data=UserInfo, result=user.Info"
-	fields := strings.Split(innerContent, ",")
-	for _, field := range fields {
-		field = strings.TrimSpace(field)
-		if field == "" {
-			continue
-		}
-
-		// 解析 fieldName=StructName 格式
-		parts := strings.SplitN(field, "=", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		fieldName := strings.TrimSpace(parts[0])
-		structName := strings.TrimSpace(parts[1])
-
-		// 解析结构体引用（可能包含包名）
-		structKey, err := p.resolveStructReference(structName, filePath)
-		if err != nil {
-			// 如果解析失败，尝试直接查找
-			structKey = structName
-		}
-
-		if _, exists := p.structInfos[structKey]; exists {
-			// 查找并替换对应的字段
-			found := false
-			for i, param := range params {
-				if param.Name == fieldName {
-					// 替换为新的结构体字段
-					params[i] = types.ResponseParam{Name: fieldName, Type: "object", Remark: param.Remark}
-
-					// 深度添加结构体的子字段
-					nestedParams := p.deepParseStruct(structKey, fieldName+".")
-					params = append(params, nestedParams...)
-					found = true
-					break
-				}
-			}
-
-			// 如果没有找到对应字段，作为新字段添加
-			if !found {
-				// 添加对象字段
-				remark := fmt.Sprintf("%s信息", fieldName)
-				params = append(params, types.ResponseParam{Name: fieldName, Type: "object", Remark: remark})
-
-				// 深度添加结构体字段
-				nestedParams := p.deepParseStruct(structKey, fieldName+".")
-				params = append(params, nestedParams...)
-			}
-		}
-	}
-
-	return params, nil
-}
-
-// deepParseStruct 深度解析结构体字段，展开嵌套结构体
-func (p *Parser) deepParseStruct(structName string, prefix string) []types.ResponseParam {
-	var params []types.ResponseParam
-
-	structInfo, exists := p.structInfos[structName]
-	if !exists {
-		return params
-	}
-
-	for _, field := range structInfo.Fields {
-		// 跳过被标记为 - 的字段（不序列化的字段）
-		if field.Name == "-" {
-			continue
-		}
-
-		// 检查是否是嵌入字段（字段名和类型相同，或者字段名包含点号表示已经展开的）
-		isEmbedded := (field.Name == field.Type)
-
-		// 清理字段类型，移除指针符号
-		cleanType := strings.TrimPrefix(field.Type, "*")
-
-		// 处理数组类型
-		if strings.HasPrefix(cleanType, "[]") {
-			// 获取数组元素类型
-			elementType := strings.TrimPrefix(cleanType, "[]")
-
-			// 添加数组字段本身
-			if !isEmbedded {
-				param := types.ResponseParam{
-					Name:     prefix + field.Name,
-					Type:     "array",
-					Required: field.Required,
-					Remark:   field.Remark,
-				}
-				params = append(params, param)
-			}
-
-			// 检查数组元素是否是结构体
-			if _, isStruct := p.structInfos[elementType]; isStruct {
-				// 递归解析数组元素结构体，使用普通的点号分隔
-				arrayPrefix := prefix + field.Name + "."
-				nestedParams := p.deepParseStruct(elementType, arrayPrefix)
-				params = append(params, nestedParams...)
-			} else if strings.Contains(elementType, ".") {
-				// 尝试解析带包名的结构体
-				for key := range p.structInfos {
-					if strings.HasSuffix(key, "."+elementType) || key == elementType {
-						// 递归解析数组元素结构体
-						arrayPrefix := prefix + field.Name + "."
-						nestedParams := p.deepParseStruct(key, arrayPrefix)
-						params = append(params, nestedParams...)
-						break
-					}
-				}
-			} else {
-				// 尝试在同一包内查找结构体
-				currentPackage := structInfo.Package
-				prefixedKey := currentPackage + "." + elementType
-				if _, isStruct := p.structInfos[prefixedKey]; isStruct {
-					// 递归解析数组元素结构体
-					arrayPrefix := prefix + field.Name + "."
-					nestedParams := p.deepParseStruct(prefixedKey, arrayPrefix)
-					params = append(params, nestedParams...)
-				}
-			}
-		} else if _, isStruct := p.structInfos[cleanType]; isStruct {
-			// 递归解析嵌套结构体
-			// 对于嵌入字段，使用当前前缀；对于普通字段，添加字段名作为前缀
-			fieldPrefix := prefix
-			if !isEmbedded {
-				// 对于普通字段，先添加对象字段本身
-				param := types.ResponseParam{
-					Name:     prefix + field.Name,
-					Type:     "object",
-					Required: field.Required,
-					Remark:   field.Remark,
-				}
-				params = append(params, param)
-				fieldPrefix = prefix + field.Name + "."
-			}
-			// 递归解析嵌套结构体的字段
-			nestedParams := p.deepParseStruct(cleanType, fieldPrefix)
-			params = append(params, nestedParams...)
-		} else {
-			// 检查是否是带包名的结构体引用
-			if strings.Contains(field.Type, ".") {
-				// 尝试解析带包名的结构体
-				found := false
-				for key := range p.structInfos {
-					// 尝试多种匹配方式
-					if key == field.Type || strings.HasSuffix(key, "."+cleanType) {
-						// 递归解析嵌套结构体
-						fieldPrefix := prefix
-						if !isEmbedded {
-							// 对于普通字段，先添加对象字段本身
-							param := types.ResponseParam{
-								Name:     prefix + field.Name,
-								Type:     "object",
-								Required: field.Required,
-								Remark:   field.Remark,
-							}
-							params = append(params, param)
-							fieldPrefix = prefix + field.Name + "."
-						}
-						// 递归解析嵌套结构体的字段
-						nestedParams := p.deepParseStruct(key, fieldPrefix)
-						params = append(params, nestedParams...)
-						found = true
-						break
-					}
-				}
-				// 如果没有找到，尝试去除包名中的数字后缀
-				if !found {
-					// 提取包名和类型名
-					parts := strings.Split(field.Type, ".")
-					if len(parts) == 2 {
-						packageName := parts[0]
-						typeName := parts[1]
-						// 去除包名中的数字后缀
-						trimmedPackageName := strings.TrimSuffix(packageName, "2")
-						trimmedPackageName = strings.TrimSuffix(trimmedPackageName, "3")
-						trimmedPackageName = strings.TrimSuffix(trimmedPackageName, "4")
-						trimmedPackageName = strings.TrimSuffix(trimmedPackageName, "5")
-						trimmedKey := trimmedPackageName + "." + typeName
-						// 尝试查找去除数字后缀的包名
-						if _, exists := p.structInfos[trimmedKey]; exists {
-							// 递归解析嵌套结构体
-							fieldPrefix := prefix
-							if !isEmbedded {
-								// 对于普通字段，先添加对象字段本身
-								param := types.ResponseParam{
-									Name:     prefix + field.Name,
-									Type:     "object",
-									Required: field.Required,
-									Remark:   field.Remark,
-								}
-								params = append(params, param)
-								fieldPrefix = prefix + field.Name + "."
-							}
-							// 递归解析嵌套结构体的字段
-							nestedParams := p.deepParseStruct(trimmedKey, fieldPrefix)
-							params = append(params, nestedParams...)
-							found = true
-						}
-					}
-				}
-				// 如果没有找到，打印警告信息
-				if !found && isEmbedded {
-					fmt.Printf("警告: 嵌入字段 %s 未找到匹配的结构体 (cleanType=%s, field.Type=%s)\n", field.Name, cleanType, field.Type)
-					fmt.Printf("可用的结构体: %v\n", p.getAvailableStructs())
-				}
-				if !found {
-					// 只有非嵌入字段才添加到参数列表
-					if !isEmbedded {
-						param := types.ResponseParam{
-							Name:     prefix + field.Name,
-							Type:     p.mapGoTypeToResponseType(field.Type),
-							Required: field.Required,
-							Remark:   field.Remark,
-						}
-						params = append(params, param)
-					}
-				}
-			} else {
-				// 尝试在同一包内查找结构体
-				currentPackage := structInfo.Package
-				prefixedKey := currentPackage + "." + cleanType
-				if _, isStruct := p.structInfos[prefixedKey]; isStruct {
-					// 递归解析嵌套结构体
-					fieldPrefix := prefix
-					if !isEmbedded {
-						// 对于普通字段，先添加对象字段本身
-						param := types.ResponseParam{
-							Name:     prefix + field.Name,
-							Type:     "object",
-							Required: field.Required,
-							Remark:   field.Remark,
-						}
-						params = append(params, param)
-						fieldPrefix = prefix + field.Name + "."
-					}
-					// 递归解析嵌套结构体的字段
-					nestedParams := p.deepParseStruct(prefixedKey, fieldPrefix)
-					params = append(params, nestedParams...)
-				} else {
-					// 只有非嵌入字段才添加到参数列表
-					if !isEmbedded {
-						param := types.ResponseParam{
-							Name:     prefix + field.Name,
-							Type:     p.mapGoTypeToResponseType(field.Type),
-							Required: field.Required,
-							Remark:   field.Remark,
-						}
-						params = append(params, param)
-					}
-				}
-			}
-		}
-	}
-
-	return params
-}
-
 // mapGoTypeToRequestType 将Go类型映射到请求参数类型
 func (p *Parser) mapGoTypeToRequestType(goType string) string {
 	// 处理指针类型
@@ -1067,8 +1352,20 @@ func (p *Parser) mapGoTypeToRequestType(goType string) string {
 		goType = goType[1:]
 	}
 
-	// 处理数组类型
-	if strings.HasPrefix(goType, "[]") {
+	// 命名类型按底层类型映射，配置了类型映射的命名类型除外
+	if namedType, exists := p.namedTypes[goType]; exists && namedType.Underlying != "" {
+		if _, known := p.knownType(goType); !known {
+			goType = namedType.Underlying
+		}
+	}
+
+	// 内置或配置的类型映射，如 time.Time、[]byte
+	if mapping, exists := p.knownType(goType); exists {
+		return p.knownRequestType(mapping)
+	}
+
+	// 处理数组类型（包括固定长度数组）
+	if expr, err := parseTypeExpr(goType); err == nil && (expr.Kind == kindSlice || expr.Kind == kindArray) {
 		return "array"
 	}
 
@@ -1087,62 +1384,14 @@ func (p *Parser) mapGoTypeToRequestType(goType string) string {
 		return "boolean"
 	case "file":
 		return "file"
-	case "interface{}":
-		return "object"
+	case "interface{}", "any":
+		return "any"
 	default:
 		// 对于自定义结构体，返回object
 		return "object"
 	}
 }
 
-// getFieldOriginalGoType 从 FieldInfo 列表中获取结构体字段的原始Go类型
-func (p *Parser) getFieldOriginalGoType(structKey, fieldName string) string {
-	structInfo, exists := p.structInfos[structKey]
-	if !exists {
-		return "object" // 默认返回object
-	}
-
-	// 首先在当前结构体的字段中查找
-	for _, field := range structInfo.Fields {
-		if field.Name == fieldName {
-			return field.Type
-		}
-	}
-
-	// 如果在当前结构体中找不到，检查嵌入字段
-	for _, field := range structInfo.Fields {
-		// 检查是否是嵌入字段（字段名和类型相同）
-		if field.Name == field.Type {
-			// 清理类型，移除指针符号
-			cleanType := strings.TrimPrefix(field.Type, "*")
-			// 尝试直接查找
-			if nestedType := p.getFieldOriginalGoType(cleanType, fieldName); nestedType != "object" {
-				return nestedType
-			}
-			// 如果找不到，尝试去除包名中的数字后缀
-			if strings.Contains(cleanType, ".") {
-				parts := strings.Split(cleanType, ".")
-				if len(parts) == 2 {
-					packageName := parts[0]
-					typeName := parts[1]
-					// 去除包名中的数字后缀
-					trimmedPackageName := strings.TrimSuffix(packageName, "2")
-					trimmedPackageName = strings.TrimSuffix(trimmedPackageName, "3")
-					trimmedPackageName = strings.TrimSuffix(trimmedPackageName, "4")
-					trimmedPackageName = strings.TrimSuffix(trimmedPackageName, "5")
-					trimmedKey := trimmedPackageName + "." + typeName
-					// 尝试查找去除数字后缀的包名
-					if nestedType := p.getFieldOriginalGoType(trimmedKey, fieldName); nestedType != "object" {
-						return nestedType
-					}
-				}
-			}
-		}
-	}
-
-	return "object" // 默认返回object
-}
-
 // getOriginalGoType 获取结构体字段的原始Go类型（已废弃，保留用于兼容）
 func (p *Parser) getOriginalGoType(structKey, fieldName string) string {
 	structInfo, exists := p.structInfos[structKey]
@@ -1152,15 +1401,15 @@ func (p *Parser) getOriginalGoType(structKey, fieldName string) string {
 
 	// 首先在当前结构体的字段中查找
 	for _, field := range structInfo.Fields {
-		if field.Name == fieldName {
+		if field.Name == fieldName && !field.JSONSkip {
 			return field.Type
 		}
 	}
 
 	// 如果在当前结构体中找不到，检查嵌入字段
 	for _, field := range structInfo.Fields {
-		// 检查是否是嵌入字段（字段名和类型相同）
-		if field.Name == field.Type {
+		// 检查是否是嵌入字段
+		if field.Embedded {
 			// 清理类型，移除指针符号和包名前缀
 			cleanType := strings.TrimPrefix(field.Type, "*")
 			// 如果类型包含包名前缀，尝试查找完整路径
@@ -1196,8 +1445,20 @@ func (p *Parser) mapGoTypeToResponseType(goType string) string {
 		goType = goType[1:]
 	}
 
-	// 处理数组类型
-	if strings.HasPrefix(goType, "[]") {
+	// 命名类型按底层类型映射，配置了类型映射的命名类型除外
+	if namedType, exists := p.namedTypes[goType]; exists && namedType.Underlying != "" {
+		if _, known := p.knownType(goType); !known {
+			goType = namedType.Underlying
+		}
+	}
+
+	// 内置或配置的类型映射，如 time.Time、[]byte
+	if mapping, exists := p.knownType(goType); exists {
+		return p.knownResponseType(mapping)
+	}
+
+	// 处理数组类型（包括固定长度数组）
+	if expr, err := parseTypeExpr(goType); err == nil && (expr.Kind == kindSlice || expr.Kind == kindArray) {
 		return "array"
 	}
 
@@ -1212,48 +1473,43 @@ func (p *Parser) mapGoTypeToResponseType(goType string) string {
 		return "number"
 	case "bool":
 		return "boolean"
-	case "interface{}":
-		return "object"
+	case "interface{}", "any":
+		return "any"
 	default:
 		// 对于自定义结构体，返回object
 		return "object"
 	}
 }
 
-// validateAPIDoc 校验API文档的必填字段
-func (p *Parser) validateAPIDoc(doc types.APIDoc) error {
-	location := fmt.Sprintf("文件: %s, 函数: %s", doc.FilePath, doc.FunctionName)
-
-	if doc.Title == "" {
-		return fmt.Errorf("%s - title字段是必填的\n修复建议: 在函数注释中添加 @title 接口标题", location)
-	}
-	if doc.Method == "" {
-		return fmt.Errorf("%s - method字段是必填的\n修复建议: 在函数注释中添加 @method get|post|put|delete等HTTP方法", location)
-	}
-	if doc.Router == "" && doc.URL == "" {
-		return fmt.Errorf("%s - router或url字段至少需要一个\n修复建议: 在函数注释中添加 @router /api/path 或 @url /api/path", location)
+// validateAPIDoc 校验API文档的必填字段，校验问题记录为错误级别的诊断信息
+func (p *Parser) validateAPIDoc(doc types.APIDoc) bool {
+	var message, suggestion string
+	switch {
+	case doc.Title == "":
+		message, suggestion = "title字段是必填的", "在函数注释中添加 @title 接口标题"
+	case doc.Method == "":
+		message, suggestion = "method字段是必填的", "在函数注释中添加 @method get|post|put|delete等HTTP方法"
+	case doc.Router == "" && doc.URL == "":
+		message, suggestion = "router或url字段至少需要一个", "在函数注释中添加 @router /api/path 或 @url /api/path"
+	default:
+		return true
 	}
-	return nil
+
+	p.diag.ReportAt(diagnostic.SeverityError, doc.FilePath, doc.Line, diagnostic.CodeRequiredField, suggestion, "函数 %s 的%s", doc.FunctionName, message)
+	return false
 }
 
 // GenerateJSON 生成JSON文档
 func (p *Parser) GenerateJSON(apiDocs []types.APIDoc) (string, error) {
-	var validationErrors []error
-
 	// 校验所有API文档
+	invalid := 0
 	for _, doc := range apiDocs {
-		if err := p.validateAPIDoc(doc); err != nil {
-			validationErrors = append(validationErrors, err)
+		if !p.validateAPIDoc(doc) {
+			invalid++
 		}
 	}
-
-	// 如果有校验错误，显示汇总信息
-	if len(validationErrors) > 0 {
-		fmt.Fprintf(os.Stderr, "发现 %d 个API文档校验问题:\n", len(validationErrors))
-		for i, err := range validationErrors {
-			fmt.Fprintf(os.Stderr, "%d. %v\n", i+1, err)
-		}
-		return "", fmt.Errorf("API文档校验失败，请修复上述问题后重试")
+	if invalid > 0 {
+		return "", fmt.Errorf("发现 %d 个API文档校验问题，请修复后重试", invalid)
 	}
 
 	jsonData, err := json.MarshalIndent(apiDocs, "", "\t")
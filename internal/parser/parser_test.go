@@ -0,0 +1,229 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// parseTestdata 解析 testdata 中的测试模块，目录中的 runapi.json 作为配置文件
+// configure 用于在解析前修改配置，如切换结构体解析模式
+func parseTestdata(t *testing.T, name string, configure ...func(*config.Config)) ([]types.APIDoc, []diagnostic.Diagnostic) {
+	t.Helper()
+	dir, err := filepath.Abs(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(dir, "")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	for _, fn := range configure {
+		fn(cfg)
+	}
+	p := NewParser(cfg)
+	docs, err := p.ParseDir()
+	if err != nil {
+		t.Fatalf("ParseDir() error = %v", err)
+	}
+	return docs, diagnostic.RelativeTo(p.Diagnostics(), dir)
+}
+
+// findDoc 按标题查找接口文档
+func findDoc(t *testing.T, docs []types.APIDoc, title string) types.APIDoc {
+	t.Helper()
+	for _, doc := range docs {
+		if doc.Title == title {
+			return doc
+		}
+	}
+	t.Fatalf("未找到接口 %s，共 %d 个接口", title, len(docs))
+	return types.APIDoc{}
+}
+
+// schemaAt 按点号分隔的字段路径查找节点，路径规则与 Schema.Walk 相同
+func schemaAt(t *testing.T, schema *types.Schema, path string) *types.Schema {
+	t.Helper()
+	if schema == nil {
+		t.Fatalf("结构为空，无法查找 %s", path)
+	}
+	var found *types.Schema
+	schema.Walk(func(p string, node *types.Schema) {
+		if p == path && found == nil {
+			found = node
+		}
+	})
+	if found == nil {
+		t.Fatalf("未找到字段 %s", path)
+	}
+	return found
+}
+
+// responseNames 返回扁平响应参数的名称
+func responseNames(params []types.ResponseParam) []string {
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Name
+	}
+	return names
+}
+
+// requestNames 返回扁平请求参数的名称
+func requestNames(params []types.RequestParam) []string {
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Name
+	}
+	return names
+}
+
+// equalNames 比较两个名称列表
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasDiagnostic 检查是否有指定代码的诊断信息
+func hasDiagnostic(diagnostics []diagnostic.Diagnostic, code string) bool {
+	for _, d := range diagnostics {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSchemaTree(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "schema")
+	if len(diagnostics) > 0 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+	doc := findDoc(t, docs, "获取用户")
+
+	want := []string{"id", "name", "address", "address.city", "friends", "friends.city"}
+	if got := responseNames(doc.ResponseBody); !equalNames(got, want) {
+		t.Errorf("response names = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		path     string
+		typ      string
+		required bool
+		remark   string
+	}{
+		{"id", "long", true, "ID"},
+		{"name", "string", false, "名称"},
+		{"address", "object", true, "地址"},
+		{"address.city", "string", true, "城市"},
+		{"friends", "array", true, "常用地址"},
+		{"friends.city", "string", true, "城市"},
+	}
+	for _, tt := range tests {
+		node := schemaAt(t, doc.ResponseSchema, tt.path)
+		if node.Type != tt.typ || node.Required != tt.required || node.Remark != tt.remark {
+			t.Errorf("%s = {type: %s, required: %t, remark: %s}, want %+v", tt.path, node.Type, node.Required, node.Remark, tt)
+		}
+	}
+
+	friends := doc.ResponseSchema.Field("friends")
+	if friends.Items == nil || friends.Items.Ref != "api.Address" || friends.Items.Field("city") == nil {
+		t.Errorf("friends items = %+v", friends.Items)
+	}
+}
+
+func TestPathPlaceholders(t *testing.T) {
+	tests := []struct {
+		router string
+		want   []string
+	}{
+		{"/api/user/{id}", []string{"id"}},
+		{"{{host}}/api/user/{id}", []string{"id"}},
+		{"/files/:bucket/*filepath", []string{"bucket", "filepath"}},
+		{"/files/{path...}", []string{"path"}},
+		{"/api/users", nil},
+	}
+	for _, tt := range tests {
+		if got := pathPlaceholders(tt.router); !equalNames(got, tt.want) {
+			t.Errorf("pathPlaceholders(%q) = %v, want %v", tt.router, got, tt.want)
+		}
+	}
+}
+
+func TestPathParams(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "pathparam")
+
+	user := findDoc(t, docs, "获取用户")
+	if len(user.Path) != 1 || user.Path[0].Name != "id" || user.Path[0].Type != "int" || user.Path[0].Require != "true" {
+		t.Errorf("path params = %+v", user.Path)
+	}
+
+	var messages []string
+	for _, d := range diagnostics {
+		if d.Code != diagnostic.CodePathParam {
+			t.Errorf("unexpected diagnostic %s", d)
+		}
+		messages = append(messages, d.Message)
+	}
+	want := []string{
+		"接口 获取文件 的路由 /files/:bucket/*filepath 中的占位符 filepath 缺少 @param filepath path 声明",
+		"接口 删除用户 的路径参数 id 在路由 /api/user 中没有对应的占位符",
+	}
+	if !equalNames(messages, want) {
+		t.Errorf("diagnostics = %v, want %v", messages, want)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "failures")
+
+	create := findDoc(t, docs, "创建订单")
+	tests := []struct {
+		status      int
+		description string
+		fields      []string
+	}{
+		{400, "参数错误", []string{"message"}},
+		{400, "字段校验失败", []string{"code", "data", "data.field"}},
+		{404, "未找到", nil},
+		{0, "", []string{"message"}},
+	}
+	if len(create.Failures) != len(tests) {
+		t.Fatalf("failures = %+v", create.Failures)
+	}
+	for i, tt := range tests {
+		failure := create.Failures[i]
+		if failure.Status != tt.status || failure.Description != tt.description {
+			t.Errorf("failure %d = {%d %s}, want {%d %s}", i, failure.Status, failure.Description, tt.status, tt.description)
+		}
+		if got := responseNames(failure.Body); !equalNames(got, tt.fields) {
+			t.Errorf("failure %d fields = %v, want %v", i, got, tt.fields)
+		}
+		if (failure.Schema == nil) != (tt.fields == nil) {
+			t.Errorf("failure %d schema = %+v", i, failure.Schema)
+		}
+	}
+
+	// 无效的状态码和未找到的结构体输出警告
+	var codes []string
+	for _, d := range diagnostics {
+		codes = append(codes, d.Code)
+	}
+	want := []string{diagnostic.CodeInvalidFailure, diagnostic.CodeStructNotFound}
+	if !equalNames(codes, want) {
+		t.Errorf("diagnostics = %v, want %v", diagnostics, want)
+	}
+	deleted := findDoc(t, docs, "删除订单")
+	if len(deleted.Failures) != 1 || deleted.Failures[0].Status != 500 || deleted.Failures[0].Description != "response.Missing 服务错误" {
+		t.Errorf("failures = %+v", deleted.Failures)
+	}
+}
@@ -0,0 +1,792 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// maxRouteDepth 跨函数分析路由注册的最大调用深度
+const maxRouteDepth = 8
+
+// routeMethods 路由注册方法名对应的请求方法，gin/echo 使用 GET，chi 使用 Get
+var routeMethods = map[string]string{
+	"GET": "get", "Get": "get",
+	"POST": "post", "Post": "post",
+	"PUT": "put", "Put": "put",
+	"DELETE": "delete", "Delete": "delete",
+	"PATCH": "patch", "Patch": "patch",
+	"HEAD": "head", "Head": "head",
+	"OPTIONS": "options", "Options": "options",
+}
+
+// route 从路由注册代码中识别的路由
+type route struct {
+	method   string        // 小写的请求方法，为空表示未限定请求方法
+	path     string        // 包含分组前缀的完整路径
+	handlers []handlerRef  // 处理函数的候选引用，按优先级排序
+	pos      token.Pos     // 注册代码的位置
+	location string        // 注册代码的位置描述，如 router.go:12
+	owner    *ast.FuncDecl // 注册路由的顶层函数，跨函数分析得到的路由为nil
+}
+
+// handlerRef 路由处理函数的引用
+type handlerRef struct {
+	name       string // 函数名或方法名
+	importPath string // 处理函数所在包的导入路径，为空表示与注册代码同包
+	dir        string // 注册代码所在目录
+	method     bool   // 是否为方法值，如 ctrl.List，ast模式下已知接收者类型时 importPath 为其所在包
+	declFile   string // 处理函数声明所在文件，仅packages模式下可确定
+}
+
+// routeFile 待识别路由注册的源文件
+type routeFile struct {
+	path    string
+	dir     string
+	file    *ast.File
+	fset    *token.FileSet
+	imports map[string]string // map[alias]packagePath
+	consts  map[string]string // 同包的字符串常量，仅ast模式使用
+	info    *gotypes.Info     // packages模式下的类型信息，ast模式为nil
+}
+
+// routeFunc 可能注册路由的函数声明
+type routeFunc struct {
+	decl *ast.FuncDecl
+	file *routeFile
+}
+
+// routeScope 函数体内路由分组变量对应的路径前缀
+type routeScope struct {
+	file      *routeFile
+	prefixes  map[string]string // key 为变量表达式，如 api、s.router
+	receivers map[string]string // 处理器变量的类型所在包的导入路径，如 h := &order.Handler{}，仅ast模式使用
+	base      string            // 未知路由变量的前缀，如 chi Mount 挂载的子路由
+	owner     *ast.FuncDecl
+	depth     int
+}
+
+// routeAnalyzer 路由注册代码分析器
+type routeAnalyzer struct {
+	p      *Parser
+	funcs  map[string][]routeFunc // key 为函数名，不包括方法
+	called map[*ast.FuncDecl]bool // 以路由分组作为参数被调用过的函数
+	routes []route
+}
+
+// docLocation 接口文档对应的处理函数位置
+type docLocation struct {
+	file string
+	dir  string
+	name string
+}
+
+// applyRoutes 从路由注册代码中识别路由，补全接口缺少的请求方法和路径，已声明的与注册的路由不一致时输出警告
+func (p *Parser) applyRoutes(apiDocs []types.APIDoc) error {
+	routes, err := p.discoverRoutes()
+	if err != nil {
+		return err
+	}
+
+	locations := make([]docLocation, len(apiDocs))
+	for i, doc := range apiDocs {
+		file, _ := filepath.Abs(doc.FilePath)
+		locations[i] = docLocation{file: file, dir: filepath.Dir(file), name: doc.FunctionName}
+	}
+
+	docRoutes := make(map[int][]route)
+	for _, r := range routes {
+		for _, i := range p.routeDocs(r, locations) {
+			docRoutes[i] = append(docRoutes[i], r)
+		}
+	}
+
+	for i := range apiDocs {
+		if len(docRoutes[i]) > 0 {
+			p.applyDocRoutes(&apiDocs[i], docRoutes[i])
+		}
+	}
+	return nil
+}
+
+// applyDocRoutes 使用处理函数注册的路由补全或校验接口的请求方法和路径
+func (p *Parser) applyDocRoutes(apiDoc *types.APIDoc, routes []route) {
+	declared := apiDoc.URL
+	if declared == "" {
+		declared = apiDoc.Router
+	}
+
+	var matched []route
+	for _, r := range routes {
+		if apiDoc.Method != "" && r.method != "" && !strings.EqualFold(apiDoc.Method, r.method) {
+			continue
+		}
+		if declared != "" && routePathKey(declared) != routePathKey(r.path) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+
+	if len(matched) == 0 {
+		p.docWarnf(*apiDoc, diagnostic.CodeRouteMismatch, "接口 %s 声明的路由 %s 与注册的路由不一致: %s",
+			apiDoc.Title, describeRoute(apiDoc.Method, declared), describeRoutes(routes))
+		return
+	}
+	if apiDoc.Method != "" && declared != "" {
+		return
+	}
+
+	if len(matched) > 1 {
+		p.docWarnf(*apiDoc, diagnostic.CodeRouteAmbiguous, "接口 %s 的处理函数注册了多个路由，使用第一个: %s", apiDoc.Title, describeRoutes(matched))
+	}
+	if apiDoc.Method == "" {
+		apiDoc.Method = matched[0].method
+	}
+	if declared == "" {
+		apiDoc.Router = matched[0].path
+	}
+}
+
+// describeRoute 返回路由的描述，如 GET /api/users
+func describeRoute(method, path string) string {
+	if method == "" {
+		return path
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+// describeRoutes 返回路由列表的描述，包含注册代码的位置
+func describeRoutes(routes []route) string {
+	var descriptions []string
+	for _, r := range routes {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", describeRoute(r.method, r.path), r.location))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// routePathKey 返回用于比较的路径，去除 {{host}} 等ShowDoc变量、协议主机和查询参数，占位符统一为 {}
+func routePathKey(router string) string {
+	path := strings.TrimSpace(router)
+	for strings.HasPrefix(path, "{{") {
+		end := strings.Index(path, "}}")
+		if end == -1 {
+			break
+		}
+		path = path[end+2:]
+	}
+	if schemeIndex := strings.Index(path, "://"); schemeIndex != -1 {
+		path = path[schemeIndex+3:]
+		if slashIndex := strings.Index(path, "/"); slashIndex != -1 {
+			path = path[slashIndex:]
+		} else {
+			path = "/"
+		}
+	}
+	if queryIndex := strings.Index(path, "?"); queryIndex != -1 {
+		path = path[:queryIndex]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if len(pathPlaceholders("/"+segment)) > 0 {
+			segments[i] = "{}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// routeDocs 返回路由处理函数对应的接口文档下标，依次尝试候选的处理函数引用
+func (p *Parser) routeDocs(r route, locations []docLocation) []int {
+	for _, ref := range r.handlers {
+		var matches []int
+		bestScore := 0
+		for i, location := range locations {
+			if location.name != ref.name {
+				continue
+			}
+			score := p.handlerScore(ref, location)
+			switch {
+			case score > bestScore:
+				bestScore = score
+				matches = []int{i}
+			case score == bestScore && score > 0:
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		if len(matches) > 1 {
+			p.diag.Warnf(r.pos, diagnostic.CodeRouteAmbiguous, "无法确定路由 %s 的处理函数 %s，存在多个同名的接口函数，可以使用 packages 解析模式",
+				describeRoute(r.method, r.path), ref.name)
+			return nil
+		}
+		return matches
+	}
+	return nil
+}
+
+// handlerScore 返回接口函数与处理函数引用的匹配程度，0表示不匹配
+func (p *Parser) handlerScore(ref handlerRef, location docLocation) int {
+	switch {
+	case ref.declFile != "":
+		if ref.declFile == location.file {
+			return 1
+		}
+		return 0
+	case ref.importPath != "":
+		// packages模式下按完整包路径匹配，ast模式下按导入路径与目录的公共后缀匹配
+		if pkg, exists := p.filePackages[location.file]; exists {
+			if pkg.PkgPath == ref.importPath {
+				return 1
+			}
+			return 0
+		}
+		return commonSuffixLen(ref.importPath, filepath.ToSlash(location.dir))
+	case ref.method:
+		// ast模式下无法确定方法值的接收者类型，优先匹配同目录的方法
+		if location.dir == ref.dir {
+			return 2
+		}
+		return 1
+	default:
+		if location.dir == ref.dir {
+			return 1
+		}
+		return 0
+	}
+}
+
+// commonSuffixLen 返回两个路径末尾相同的路径段数量
+func commonSuffixLen(a, b string) int {
+	aSegments := strings.Split(a, "/")
+	bSegments := strings.Split(b, "/")
+	count := 0
+	for count < len(aSegments) && count < len(bSegments) &&
+		aSegments[len(aSegments)-1-count] == bSegments[len(bSegments)-1-count] {
+		count++
+	}
+	return count
+}
+
+// discoverRoutes 分析扫描目录中的路由注册代码，支持 net/http、gin、echo 和 chi 的注册方式及分组前缀
+func (p *Parser) discoverRoutes() ([]route, error) {
+	files, err := p.routeFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	a := &routeAnalyzer{
+		p:      p,
+		funcs:  make(map[string][]routeFunc),
+		called: make(map[*ast.FuncDecl]bool),
+	}
+	for _, file := range files {
+		for _, decl := range file.file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && funcDecl.Body != nil {
+				a.funcs[funcDecl.Name.Name] = append(a.funcs[funcDecl.Name.Name], routeFunc{decl: funcDecl, file: file})
+			}
+		}
+	}
+
+	for _, file := range files {
+		for _, decl := range file.file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				a.walk(funcDecl.Body, newRouteScope(file, "", funcDecl, 0))
+			}
+		}
+	}
+
+	// 以路由分组作为参数调用的函数已经在调用处按前缀分析过，不再使用其单独分析的结果
+	var routes []route
+	for _, r := range a.routes {
+		if r.owner == nil || !a.called[r.owner] {
+			routes = append(routes, r)
+		}
+	}
+	return routes, nil
+}
+
+// routeFiles 返回待识别路由注册的源文件，packages模式下使用已加载的语法树和类型信息
+func (p *Parser) routeFiles() ([]*routeFile, error) {
+	var files []*routeFile
+
+	if len(p.filePackages) > 0 {
+		for path, pkg := range p.filePackages {
+			for i, file := range pkg.Syntax {
+				if filepath.Clean(pkg.CompiledGoFiles[i]) == path {
+					files = append(files, &routeFile{path: path, dir: filepath.Dir(path), file: file, fset: p.fset, info: pkg.TypesInfo})
+				}
+			}
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, dir := range p.scanDirs() {
+			err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !p.includeVendor && (strings.Contains(path, "/vendor/") || strings.HasPrefix(strings.TrimPrefix(path, "./"), "vendor/")) {
+					return nil
+				}
+				if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+					return nil
+				}
+
+				absPath, err := filepath.Abs(path)
+				if err != nil || seen[absPath] {
+					return err
+				}
+				seen[absPath] = true
+
+				file, err := parser.ParseFile(p.fset, path, nil, parser.SkipObjectResolution)
+				if err != nil {
+					return err
+				}
+				if _, exists := p.packageImports[path]; !exists {
+					p.parseImports(path, file)
+				}
+				files = append(files, &routeFile{path: path, dir: filepath.Dir(absPath), file: file, fset: p.fset, imports: p.packageImports[path]})
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("识别目录 %s 中的路由失败: %v", dir, err)
+			}
+		}
+	}
+
+	// 同目录的文件共享字符串常量，用于解析 const apiPrefix = "/api" 形式的路由前缀
+	consts := make(map[string]map[string]string)
+	for _, file := range files {
+		if file.info != nil {
+			continue
+		}
+		if consts[file.dir] == nil {
+			consts[file.dir] = make(map[string]string)
+		}
+		file.consts = consts[file.dir]
+		for _, decl := range file.file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for i, name := range valueSpec.Names {
+					if i >= len(valueSpec.Values) {
+						break
+					}
+					if lit, ok := valueSpec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						if value, err := strconv.Unquote(lit.Value); err == nil {
+							file.consts[name.Name] = value
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+	return files, nil
+}
+
+// walk 按源码顺序分析路由注册和路由分组变量的赋值
+func (a *routeAnalyzer) walk(node ast.Node, scope *routeScope) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					scope.assign(gotypes.ExprString(lhs), n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i, name := range n.Names {
+					scope.assign(name.Name, n.Values[i])
+				}
+			}
+		case *ast.CallExpr:
+			return a.call(n, scope)
+		}
+		return true
+	})
+}
+
+// call 分析函数调用，返回是否继续分析调用的子节点
+func (a *routeAnalyzer) call(call *ast.CallExpr, scope *routeScope) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		a.follow(call, call.Fun, scope, scope.base)
+		return true
+	}
+
+	name := sel.Sel.Name
+	args := call.Args
+	prefix := scope.prefix(sel.X)
+
+	switch {
+	case routeMethods[name] != "" && len(args) >= 2:
+		// gin/echo 的 GET(path, handlers...)，chi 的 Get(path, handler)
+		if path, ok := scope.str(args[0]); ok {
+			a.add(routeMethods[name], joinRoutePath(prefix, path), args[1:], call, scope)
+		}
+	case name == "Any" && len(args) >= 2:
+		if path, ok := scope.str(args[0]); ok {
+			a.add("", joinRoutePath(prefix, path), args[1:], call, scope)
+		}
+	case (name == "Handle" || name == "HandleFunc" || name == "Add" || name == "Method" || name == "MethodFunc") && len(args) >= 2:
+		// gin 的 Handle(method, path, handlers...)、echo 的 Add(method, path, handler)、chi 的 Method(method, path, handler)
+		method, methodOK := scope.str(args[0])
+		if path, ok := scope.str(args[1]); ok && methodOK && len(args) >= 3 {
+			if method, exists := routeMethods[strings.ToUpper(method)]; exists {
+				a.add(method, joinRoutePath(prefix, path), args[2:], call, scope)
+			}
+			return true
+		}
+		// net/http 和 chi 的 Handle(pattern, handler)，Go 1.22 起 pattern 可以带请求方法，如 "POST /users"
+		if methodOK && (name == "Handle" || name == "HandleFunc") {
+			method, path := splitRoutePattern(method)
+			a.add(method, joinRoutePath(prefix, path), args[1:], call, scope)
+		}
+	case name == "Route" && len(args) == 2:
+		// chi 的 Route(path, func(r chi.Router) {...})
+		if path, ok := scope.str(args[0]); ok {
+			if lit, ok := args[1].(*ast.FuncLit); ok {
+				a.walkFuncLit(lit, joinRoutePath(prefix, path), scope)
+				return false
+			}
+		}
+	case name == "Group" && len(args) == 1:
+		// chi 的 Group(func(r chi.Router) {...})，不改变前缀
+		if lit, ok := args[0].(*ast.FuncLit); ok {
+			a.walkFuncLit(lit, prefix, scope)
+			return false
+		}
+	case name == "Mount" && len(args) == 2:
+		// chi 的 Mount(path, subRouter())，子路由函数中注册的路由使用挂载路径作为前缀
+		if path, ok := scope.str(args[0]); ok {
+			if sub, ok := args[1].(*ast.CallExpr); ok {
+				a.follow(sub, sub.Fun, scope, joinRoutePath(prefix, path))
+			}
+		}
+	default:
+		// 调用其他包中注册路由的函数，如 user.RegisterRoutes(api)
+		a.follow(call, sel, scope, scope.base)
+	}
+	return true
+}
+
+// walkFuncLit 分析注册路由的回调函数，回调的第一个参数为带前缀的路由分组
+func (a *routeAnalyzer) walkFuncLit(lit *ast.FuncLit, prefix string, scope *routeScope) {
+	inner := scope.child()
+	if params := paramNames(lit.Type.Params); len(params) > 0 {
+		inner.prefixes[params[0]] = prefix
+	}
+	a.walk(lit.Body, inner)
+}
+
+// follow 分析以路由分组作为参数调用的函数，如 registerUser(api)，被调用函数中的路由使用参数对应的前缀
+// base 不为空时被调用函数中未知的路由变量使用该前缀，用于 chi 的 Mount
+func (a *routeAnalyzer) follow(call *ast.CallExpr, fun ast.Expr, scope *routeScope, base string) {
+	if scope.depth >= maxRouteDepth {
+		return
+	}
+	target, ok := a.lookupFunc(fun, scope)
+	if !ok {
+		return
+	}
+
+	params := paramNames(target.decl.Type.Params)
+	inner := newRouteScope(target.file, base, nil, scope.depth+1)
+	passed := false
+	for i, arg := range call.Args {
+		if i >= len(params) {
+			break
+		}
+		if prefix, exists := scope.prefixes[gotypes.ExprString(arg)]; exists {
+			inner.prefixes[params[i]] = prefix
+			passed = true
+		}
+	}
+	if !passed && base == scope.base {
+		return
+	}
+
+	a.called[target.decl] = true
+	a.walk(target.decl.Body, inner)
+}
+
+// lookupFunc 查找被调用的函数声明，packages模式下使用类型信息，ast模式下按函数名和包路径匹配
+func (a *routeAnalyzer) lookupFunc(fun ast.Expr, scope *routeScope) (routeFunc, bool) {
+	refs := scope.handlerRefs(fun)
+	if len(refs) != 1 || refs[0].method {
+		return routeFunc{}, false
+	}
+	ref := refs[0]
+
+	var candidates []routeFunc
+	bestScore := 0
+	for _, candidate := range a.funcs[ref.name] {
+		path, _ := filepath.Abs(candidate.file.path)
+		score := a.p.handlerScore(ref, docLocation{file: path, dir: candidate.file.dir, name: ref.name})
+		switch {
+		case score > bestScore:
+			bestScore = score
+			candidates = []routeFunc{candidate}
+		case score == bestScore && score > 0:
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) != 1 {
+		return routeFunc{}, false
+	}
+	return candidates[0], true
+}
+
+// add 记录路由，args 为处理函数和中间件参数
+// gin 的处理函数在中间件之后，echo 的处理函数在中间件之前，因此从后往前依次作为候选
+func (a *routeAnalyzer) add(method, path string, args []ast.Expr, call *ast.CallExpr, scope *routeScope) {
+	r := route{method: method, path: path, owner: scope.owner}
+	for i := len(args) - 1; i >= 0; i-- {
+		r.handlers = append(r.handlers, scope.handlerRefs(args[i])...)
+	}
+	if len(r.handlers) == 0 {
+		return
+	}
+
+	position := a.p.fset.Position(call.Pos())
+	r.pos = call.Pos()
+	r.location = fmt.Sprintf("%s:%d", filepath.Base(position.Filename), position.Line)
+	a.routes = append(a.routes, r)
+}
+
+// newRouteScope 创建函数体的路由作用域
+func newRouteScope(file *routeFile, base string, owner *ast.FuncDecl, depth int) *routeScope {
+	return &routeScope{
+		file:      file,
+		prefixes:  make(map[string]string),
+		receivers: make(map[string]string),
+		base:      base,
+		owner:     owner,
+		depth:     depth,
+	}
+}
+
+// child 返回继承当前变量的子作用域
+func (s *routeScope) child() *routeScope {
+	child := newRouteScope(s.file, s.base, s.owner, s.depth)
+	for key, prefix := range s.prefixes {
+		child.prefixes[key] = prefix
+	}
+	for key, importPath := range s.receivers {
+		child.receivers[key] = importPath
+	}
+	return child
+}
+
+// assign 记录路由分组变量的前缀和处理器变量的类型所在包，重新赋值为其他值时移除
+func (s *routeScope) assign(name string, value ast.Expr) {
+	if prefix, known := s.groupPrefix(value); known {
+		s.prefixes[name] = prefix
+	} else {
+		delete(s.prefixes, name)
+	}
+
+	if importPath, ok := s.receiverPackage(value); ok {
+		s.receivers[name] = importPath
+	} else {
+		delete(s.receivers, name)
+	}
+}
+
+// receiverPackage 返回 &order.Handler{}、order.Handler{} 或 order.NewHandler() 所在包的导入路径
+func (s *routeScope) receiverPackage(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		return s.receiverPackage(e.X)
+	case *ast.CompositeLit:
+		return s.receiverPackage(e.Type)
+	case *ast.CallExpr:
+		return s.receiverPackage(e.Fun)
+	case *ast.SelectorExpr:
+		return s.importPath(e.X)
+	}
+	return "", false
+}
+
+// prefix 返回路由变量或分组表达式的路径前缀，如 r.Group("/api").Group("/v1") 为 /api/v1
+func (s *routeScope) prefix(expr ast.Expr) string {
+	prefix, _ := s.groupPrefix(expr)
+	return prefix
+}
+
+// groupPrefix 返回表达式的路径前缀，以及表达式是否为已知的路由分组
+// 未知的表达式使用默认前缀，如 gin.New() 创建的路由
+func (s *routeScope) groupPrefix(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return s.groupPrefix(e.X)
+	case *ast.UnaryExpr:
+		return s.groupPrefix(e.X)
+	case *ast.StarExpr:
+		return s.groupPrefix(e.X)
+	case *ast.CallExpr:
+		// r.Group("/api") 带前缀，r.With(middleware) 等其他调用沿用接收者的前缀
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return s.base, false
+		}
+		prefix, known := s.groupPrefix(sel.X)
+		if sel.Sel.Name == "Group" && len(e.Args) > 0 {
+			if path, ok := s.str(e.Args[0]); ok {
+				return joinRoutePath(prefix, path), true
+			}
+		}
+		return prefix, known
+	}
+	if prefix, exists := s.prefixes[gotypes.ExprString(expr)]; exists {
+		return prefix, true
+	}
+	return s.base, false
+}
+
+// str 返回字符串常量表达式的值，支持字符串字面量和拼接，packages模式下支持常量
+func (s *routeScope) str(expr ast.Expr) (string, bool) {
+	if s.file.info != nil {
+		if tv, exists := s.file.info.Types[expr]; exists && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value), true
+		}
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.BinaryExpr:
+		left, leftOK := s.str(e.X)
+		right, rightOK := s.str(e.Y)
+		return left + right, leftOK && rightOK && e.Op.String() == "+"
+	case *ast.ParenExpr:
+		return s.str(e.X)
+	case *ast.Ident:
+		value, exists := s.file.consts[e.Name]
+		return value, exists
+	}
+	return "", false
+}
+
+// handlerRefs 返回处理函数参数对应的候选引用
+// 包装调用如 http.HandlerFunc(user.Login)、gin.WrapF(h) 依次使用其参数，返回处理函数的工厂函数如 user.List() 使用函数本身
+func (s *routeScope) handlerRefs(expr ast.Expr) []handlerRef {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return s.handlerRefs(e.X)
+	case *ast.UnaryExpr:
+		return s.handlerRefs(e.X)
+	case *ast.CallExpr:
+		var refs []handlerRef
+		for i := len(e.Args) - 1; i >= 0; i-- {
+			refs = append(refs, s.handlerRefs(e.Args[i])...)
+		}
+		return append(refs, s.handlerRefs(e.Fun)...)
+	case *ast.Ident:
+		return []handlerRef{{name: e.Name, dir: s.file.dir, declFile: s.declFile(e)}}
+	case *ast.SelectorExpr:
+		ref := handlerRef{name: e.Sel.Name, dir: s.file.dir, declFile: s.declFile(e.Sel)}
+		if importPath, ok := s.importPath(e.X); ok {
+			ref.importPath = importPath
+		} else {
+			ref.method = true
+			ref.importPath = s.receivers[gotypes.ExprString(e.X)]
+		}
+		return []handlerRef{ref}
+	}
+	return nil
+}
+
+// declFile 返回函数或方法声明所在的文件，仅packages模式下可确定
+func (s *routeScope) declFile(ident *ast.Ident) string {
+	if s.file.info == nil {
+		return ""
+	}
+	fn, ok := s.file.info.Uses[ident].(*gotypes.Func)
+	if !ok || !fn.Pos().IsValid() {
+		return ""
+	}
+	return filepath.Clean(s.file.fset.Position(fn.Pos()).Filename)
+}
+
+// importPath 返回包名表达式对应的导入路径
+func (s *routeScope) importPath(expr ast.Expr) (string, bool) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	if s.file.info != nil {
+		if pkgName, ok := s.file.info.Uses[ident].(*gotypes.PkgName); ok {
+			return pkgName.Imported().Path(), true
+		}
+		return "", false
+	}
+	importPath, exists := s.file.imports[ident.Name]
+	return importPath, exists
+}
+
+// joinRoutePath 拼接分组前缀和路径
+func joinRoutePath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// splitRoutePattern 拆分 net/http 的路由模式，如 "POST /users/{id}" 和 "example.com/users"
+func splitRoutePattern(pattern string) (string, string) {
+	method := ""
+	pattern = strings.TrimSpace(pattern)
+	if before, after, found := strings.Cut(pattern, " "); found {
+		if mapped, exists := routeMethods[strings.ToUpper(before)]; exists {
+			method = mapped
+			pattern = strings.TrimSpace(after)
+		}
+	}
+	// 带主机名的模式只保留路径部分
+	if slashIndex := strings.Index(pattern, "/"); slashIndex > 0 {
+		pattern = pattern[slashIndex:]
+	}
+	return method, pattern
+}
+
+// paramNames 返回函数参数名列表，未命名的参数为 _
+func paramNames(fields *ast.FieldList) []string {
+	var names []string
+	if fields == nil {
+		return names
+	}
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			names = append(names, "_")
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
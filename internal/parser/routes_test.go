@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+)
+
+func TestRouteHelpers(t *testing.T) {
+	patterns := []struct {
+		pattern string
+		method  string
+		path    string
+	}{
+		{"/users", "", "/users"},
+		{"POST /users/{id}", "post", "/users/{id}"},
+		{"example.com/users", "", "/users"},
+		{"GET example.com/users", "get", "/users"},
+	}
+	for _, tt := range patterns {
+		if method, path := splitRoutePattern(tt.pattern); method != tt.method || path != tt.path {
+			t.Errorf("splitRoutePattern(%q) = %q, %q, want %q, %q", tt.pattern, method, path, tt.method, tt.path)
+		}
+	}
+
+	joins := []struct {
+		prefix, path, want string
+	}{
+		{"", "/users", "/users"},
+		{"/api/", "/users", "/api/users"},
+		{"/api", "users", "/api/users"},
+		{"/api", "", "/api"},
+	}
+	for _, tt := range joins {
+		if got := joinRoutePath(tt.prefix, tt.path); got != tt.want {
+			t.Errorf("joinRoutePath(%q, %q) = %q, want %q", tt.prefix, tt.path, got, tt.want)
+		}
+	}
+
+	// 不同写法的占位符视为相同路径
+	if routePathKey("{{host}}/users/:id") != routePathKey("/users/{id}") {
+		t.Errorf("routePathKey() differs for equivalent paths")
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	for _, resolver := range []string{config.ResolverAST, config.ResolverPackages} {
+		t.Run(resolver, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "routes", useResolver(resolver))
+
+			tests := []struct {
+				title  string
+				method string
+				router string
+			}{
+				{"用户列表", "get", "/api/v1/users"},
+				{"导出用户", "get", "/api/v1/export"},
+				{"健康检查", "get", "/health"},
+				{"登录", "post", "/login"},
+				{"订单详情", "get", "/api/v1/orders/{id}"},
+				{"创建订单", "post", "/api/v1/orders"},
+			}
+			for _, tt := range tests {
+				doc := findDoc(t, docs, tt.title)
+				if doc.Method != tt.method || doc.Router != tt.router {
+					t.Errorf("%s = %s %s, want %s %s", tt.title, doc.Method, doc.Router, tt.method, tt.router)
+				}
+			}
+
+			// 已声明的路由不会被覆盖
+			if doc := findDoc(t, docs, "获取用户"); doc.Method != "post" || doc.URL != "/api/v1/users/{id}" || doc.Router != "" {
+				t.Errorf("获取用户 = %s %s %s", doc.Method, doc.URL, doc.Router)
+			}
+
+			want := []string{
+				diagnostic.CodeRouteMismatch + ": 接口 获取用户 声明的路由 POST /api/v1/users/{id} 与注册的路由不一致: GET /api/v1/users/:id (user.go:12)",
+				diagnostic.CodeRouteAmbiguous + ": 接口 导出用户 的处理函数注册了多个路由，使用第一个: GET /api/v1/export (user.go:13), POST /api/v1/export (user.go:14)",
+			}
+			var got []string
+			for _, d := range diagnostics {
+				got = append(got, d.Code+": "+d.Message)
+			}
+			if !equalNames(got, want) {
+				t.Errorf("diagnostics = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRoutesDisabled(t *testing.T) {
+	docs, _ := parseTestdata(t, "routes", func(cfg *config.Config) {
+		cfg.Scan.Routes = false
+	})
+	if doc := findDoc(t, docs, "用户列表"); doc.Method != "" || doc.Router != "" {
+		t.Errorf("用户列表 = %s %s, want empty", doc.Method, doc.Router)
+	}
+}
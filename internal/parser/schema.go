@@ -0,0 +1,542 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// schemaContext 构建树形结构时的展开状态，用于检测循环引用和限制展开深度
+type schemaContext struct {
+	visiting map[string]bool // 当前展开路径上的结构体
+	depth    int             // 当前对象的嵌套深度
+	request  bool            // 是否为请求体，请求体中 binding/validate 标签的 required 规则决定是否必传
+}
+
+// newSchemaContext 创建展开状态，depth 为起始嵌套深度
+func newSchemaContext(depth int) *schemaContext {
+	return &schemaContext{visiting: make(map[string]bool), depth: depth}
+}
+
+// buildStructSchema 构建结构体的树形结构
+func (p *Parser) buildStructSchema(structKey string) *types.Schema {
+	return p.buildStructSchemaAt(newSchemaContext(0), structKey)
+}
+
+// buildRequestSchema 构建请求体结构体的树形结构
+func (p *Parser) buildRequestSchema(structKey string) *types.Schema {
+	ctx := newSchemaContext(0)
+	ctx.request = true
+	return p.buildStructSchemaAt(ctx, structKey)
+}
+
+// buildStructSchemaAt 在指定的展开状态下构建结构体的树形结构
+func (p *Parser) buildStructSchemaAt(ctx *schemaContext, structKey string) *types.Schema {
+	node := &types.Schema{
+		Type:   "object",
+		GoType: structKey,
+		Ref:    structKey,
+	}
+	p.expandStruct(ctx, node, structKey)
+	return node
+}
+
+// expandStruct 展开结构体的子字段
+// 结构体已在当前展开路径上时标记为循环引用，超过最大展开深度时标记为截断，均不再展开
+func (p *Parser) expandStruct(ctx *schemaContext, node *types.Schema, structKey string) {
+	if ctx.visiting[structKey] {
+		node.Circular = true
+		return
+	}
+	if p.maxDepth > 0 && ctx.depth >= p.maxDepth {
+		node.Truncated = true
+		return
+	}
+
+	ctx.visiting[structKey] = true
+	ctx.depth++
+	node.Children = p.buildStructFields(ctx, structKey)
+	ctx.depth--
+	delete(ctx.visiting, structKey)
+}
+
+// buildStructFields 构建结构体的字段节点，嵌入字段的子字段提升到当前层级
+func (p *Parser) buildStructFields(ctx *schemaContext, structKey string) []*types.Schema {
+	structInfo, exists := p.structInfos[structKey]
+	if !exists {
+		return nil
+	}
+	return p.buildFieldList(ctx, structKey, structInfo.Fields)
+}
+
+// jsonField 按 encoding/json 的规则展开嵌入字段后的字段
+type jsonField struct {
+	field     types.FieldInfo
+	structKey string   // 字段所属的结构体
+	embedded  []string // 字段经过的嵌入结构体，构建时视为正在展开
+	tagged    bool     // 是否通过json标签指定名称
+}
+
+// jsonFields 按 encoding/json 的可见性规则展开字段列表
+// 没有json标签名称的嵌入结构体提升子字段，带标签名称的嵌入结构体和嵌入的非结构体类型作为普通字段，
+// 未导出的嵌入非结构体类型被忽略；同名字段只保留嵌入层级最浅的一个，同一层级有多个时只保留唯一带标签的一个，否则全部忽略
+func (p *Parser) jsonFields(ctx *schemaContext, structKey string, fields []types.FieldInfo) []jsonField {
+	var candidates []jsonField
+	var collect func(structKey string, fields []types.FieldInfo, embedded []string)
+	collect = func(structKey string, fields []types.FieldInfo, embedded []string) {
+		for _, field := range fields {
+			// 跳过 json 标签为 - 的字段
+			if field.JSONSkip {
+				continue
+			}
+
+			jsonName, _, _ := p.extractJSONTagInfo(field.Tag)
+			tagged := jsonName != ""
+			if field.Embedded && !tagged {
+				switch {
+				case field.Ref != "":
+					// 嵌入结构体已在展开路径上时其字段都被更浅的字段覆盖，直接忽略
+					if !ctx.visiting[field.Ref] && !containsString(embedded, field.Ref) {
+						collect(field.Ref, p.structInfos[field.Ref].Fields, append(embedded[:len(embedded):len(embedded)], field.Ref))
+					}
+					continue
+				case !isExported(field.GoName):
+					continue
+				case !p.isEmbeddableType(structKey, field.Type):
+					p.warnf(diagnostic.CodeEmbedding, "结构体 %s 的嵌入字段 %s 未找到匹配的结构体", structKey, field.Type)
+					continue
+				}
+			}
+
+			candidates = append(candidates, jsonField{field: field, structKey: structKey, embedded: embedded, tagged: tagged})
+		}
+	}
+	collect(structKey, fields, nil)
+
+	var visible []jsonField
+	for _, candidate := range candidates {
+		if dominantField(candidate, candidates) {
+			visible = append(visible, candidate)
+		}
+	}
+	return visible
+}
+
+// dominantField 判断字段是否为同名字段中的主导字段：嵌入层级最浅，同一层级有多个时为唯一带标签的一个
+func dominantField(field jsonField, candidates []jsonField) bool {
+	depth := len(field.embedded)
+	var sameDepth []jsonField
+	for _, other := range candidates {
+		if other.field.Name != field.field.Name {
+			continue
+		}
+		if len(other.embedded) < depth {
+			return false
+		}
+		if len(other.embedded) == depth {
+			sameDepth = append(sameDepth, other)
+		}
+	}
+	if len(sameDepth) == 1 {
+		return true
+	}
+
+	tagged := 0
+	for _, other := range sameDepth {
+		if other.tagged {
+			tagged++
+		}
+	}
+	return field.tagged && tagged == 1
+}
+
+// isEmbeddableType 嵌入的非结构体类型能否作为普通字段，如同包的命名类型或配置了类型映射的类型
+// 其他包中未解析到的类型可能是结构体，无法确定是否提升子字段
+func (p *Parser) isEmbeddableType(structKey, goType string) bool {
+	goType = strings.TrimPrefix(goType, "*")
+	if !strings.Contains(goType, ".") {
+		return true
+	}
+	if _, known := p.knownType(goType); known {
+		return true
+	}
+	_, isNamed := p.findNamedType(p.structInfos[structKey].Package, p.structInfos[structKey].FilePath, goType)
+	return isNamed
+}
+
+// buildFieldList 构建字段列表的节点，structKey 为字段所属的结构体，用于输出警告
+func (p *Parser) buildFieldList(ctx *schemaContext, structKey string, fields []types.FieldInfo) []*types.Schema {
+	var children []*types.Schema
+
+	for _, visible := range p.jsonFields(ctx, structKey, fields) {
+		field := visible.field
+		expr, err := parseTypeExpr(field.Type)
+		if err != nil {
+			expr = &typeExpr{Kind: kindNamed, Name: field.Type}
+		}
+
+		// chan 和 func 类型无法被JSON序列化
+		if element := expr.element(); strings.HasPrefix(element.Name, "chan ") || strings.HasPrefix(element.Name, "func(") {
+			continue
+		}
+
+		// 提升的字段在嵌入结构体的上下文中构建，嵌入结构体视为正在展开
+		for _, key := range visible.embedded {
+			ctx.visiting[key] = true
+		}
+		node := p.buildTypeSchema(ctx, visible.structKey, expr, field)
+		for _, key := range visible.embedded {
+			delete(ctx.visiting, key)
+		}
+		node.Name = field.Name
+		node.Required = field.Required
+		if required, ok := hasRequiredRule(field.Tag); ok && ctx.request {
+			node.Required = required
+		}
+		node.Remark = field.Remark
+		node.Deprecated = field.Deprecated
+		node.DeprecatedNote = field.DeprecatedNote
+		node.Example = field.Example
+		applyConstraints(node, field.Constraints)
+		if field.JSONString {
+			applyJSONString(node)
+		}
+
+		children = append(children, node)
+	}
+
+	return children
+}
+
+// applyConstraints 设置节点的校验约束，数组字段只保留元素个数，其余约束作用于元素
+// 字段标签的约束优先，命名类型的可选值作为补充
+func applyConstraints(node *types.Schema, constraints types.Constraints) {
+	if node.Items == nil {
+		node.Constraints = constraints.Merge(node.Constraints)
+		return
+	}
+
+	// 固定长度数组的元素个数作为补充
+	node.Constraints = types.Constraints{MinItems: constraints.MinItems, MaxItems: constraints.MaxItems}.Merge(node.Constraints)
+	constraints.MinItems, constraints.MaxItems = nil, nil
+
+	element := node.Items
+	for element.Items != nil {
+		element = element.Items
+	}
+	element.Constraints = constraints.Merge(element.Constraints)
+}
+
+// arrayLengthConstraints 返回固定长度数组的元素个数约束，如 [3]int 的元素个数为 3，其他类型返回空约束
+func arrayLengthConstraints(expr *typeExpr) types.Constraints {
+	if expr.Kind != kindArray {
+		return types.Constraints{}
+	}
+	length, err := strconv.Atoi(expr.Len)
+	if err != nil {
+		return types.Constraints{}
+	}
+	minItems, maxItems := length, length
+	return types.Constraints{MinItems: &minItems, MaxItems: &maxItems}
+}
+
+// applyJSONString 处理json标签的 string 选项，数值和布尔值序列化为JSON字符串，其他类型不受影响
+// long 类型保留 int64 格式，便于客户端识别超出JavaScript精度的整数
+func applyJSONString(node *types.Schema) {
+	switch node.Type {
+	case "long":
+		if node.Format == "" {
+			node.Format = "int64"
+		}
+	case "int", "number", "boolean":
+	default:
+		return
+	}
+	node.Type = "string"
+}
+
+// buildTypeSchema 根据类型表达式构建节点
+// 指针为可为空的节点，切片和数组为 array，map 为带 AdditionalProperties 的 object
+func (p *Parser) buildTypeSchema(ctx *schemaContext, structKey string, expr *typeExpr, field types.FieldInfo) *types.Schema {
+	// 内置或配置的类型映射优先，如 time.Time、[]byte
+	if node, ok := p.knownTypeSchema(expr.String()); ok {
+		return node
+	}
+
+	switch expr.Kind {
+	case kindPointer:
+		node := p.buildTypeSchema(ctx, structKey, expr.Elem, field)
+		node.GoType = "*" + node.GoType
+		node.Nullable = true
+		return node
+	case kindSlice, kindArray:
+		return &types.Schema{
+			Type:        "array",
+			GoType:      expr.String(),
+			Items:       p.buildTypeSchema(ctx, structKey, expr.Elem, field),
+			Constraints: arrayLengthConstraints(expr),
+		}
+	case kindMap:
+		return &types.Schema{
+			Type:                 "object",
+			GoType:               expr.String(),
+			AdditionalProperties: p.buildTypeSchema(ctx, structKey, expr.Elem, field),
+		}
+	}
+
+	namedKey, isNamed := p.findNamedType(p.structInfos[structKey].Package, p.structInfos[structKey].FilePath, expr.Name)
+	// 按解析后的结构体或命名类型查找类型映射
+	if node, ok := p.knownTypeSchema(field.Ref, namedKey); ok {
+		return node
+	}
+
+	node := &types.Schema{GoType: expr.String()}
+	switch {
+	case expr.Name == "struct{}":
+		// 匿名结构体在原位置展开
+		node.Type = "object"
+		if p.maxDepth > 0 && ctx.depth >= p.maxDepth {
+			node.Truncated = true
+			break
+		}
+		ctx.depth++
+		node.Children = p.buildFieldList(ctx, structKey, field.Fields)
+		ctx.depth--
+	case field.Ref != "":
+		node.Type = "object"
+		node.Ref = field.Ref
+		p.expandStruct(ctx, node, field.Ref)
+	case isNamed && p.isCompositeNamedType(namedKey):
+		return p.buildCompositeSchema(ctx, structKey, namedKey, field)
+	case isNamed:
+		// 命名类型使用底层类型，同包中该类型的常量作为可选值
+		namedType := p.namedTypes[namedKey]
+		node.Type = p.mapGoTypeToResponseType(namedType.Underlying)
+		node.GoType = namedKey
+		node.Constraints = namedType.EnumConstraints()
+		if mapping, known := p.knownType(namedType.Underlying); known {
+			node.Format = mapping.Format
+		}
+	default:
+		node.Type = p.mapGoTypeToResponseType(expr.Name)
+		if expr.Name == "any" || expr.Name == "interface{}" {
+			node.Nullable = true
+		}
+	}
+
+	return node
+}
+
+// isCompositeNamedType 是否为底层类型为切片、数组或map的命名类型，配置了类型映射的除外，如 type Raw []byte
+func (p *Parser) isCompositeNamedType(namedKey string) bool {
+	underlying := p.namedTypes[namedKey].Underlying
+	if _, known := p.knownType(underlying); known {
+		return false
+	}
+	expr, err := parseTypeExpr(underlying)
+	return err == nil && expr.Kind != kindNamed
+}
+
+// buildCompositeSchema 按底层类型构建切片、数组或map命名类型的节点，如 type Tags []string 为 array
+// 底层类型中的类型已解析为完整的key，递归引用自身的类型（如 type Tree map[string]Tree）在循环处不再展开
+func (p *Parser) buildCompositeSchema(ctx *schemaContext, structKey, namedKey string, field types.FieldInfo) *types.Schema {
+	underlying := p.namedTypes[namedKey].Underlying
+	if ctx.visiting[namedKey] {
+		return &types.Schema{Type: p.mapGoTypeToResponseType(underlying), GoType: namedKey, Circular: true}
+	}
+
+	expr, _ := parseTypeExpr(underlying)
+	element := types.FieldInfo{Type: underlying, Ref: p.fieldRef("", "", expr)}
+	ctx.visiting[namedKey] = true
+	node := p.buildTypeSchema(ctx, structKey, expr, element)
+	delete(ctx.visiting, namedKey)
+	node.GoType = namedKey
+	return node
+}
+
+// buildResponseSchema 构建响应结构，支持 Response{data=UserInfo} 或 Response{result=user.Info} 格式的字段覆盖
+func (p *Parser) buildResponseSchema(responseValue string, filePath string) (*types.Schema, error) {
+	if strings.Count(responseValue, "{") != strings.Count(responseValue, "}") ||
+		strings.Contains(responseValue, "{") && !strings.HasSuffix(responseValue, "}") {
+		return nil, &schemaError{code: diagnostic.CodeInvalidResponse, message: "响应格式错误: " + responseValue + "，应为 结构体{字段名=结构体}"}
+	}
+
+	baseStructName := responseValue
+	innerContent := ""
+	if leftBrace := strings.Index(responseValue, "{"); leftBrace != -1 && strings.HasSuffix(responseValue, "}") {
+		baseStructName = strings.TrimSpace(responseValue[:leftBrace])
+		innerContent = strings.TrimSpace(responseValue[leftBrace+1 : len(responseValue)-1])
+	}
+
+	// 解析基础结构体名称（可能包含包名）
+	baseStructKey, err := p.resolveStructReference(baseStructName, filePath)
+	if err != nil {
+		// 如果解析失败，尝试直接查找
+		baseStructKey = baseStructName
+	}
+
+	var schema *types.Schema
+	if _, exists := p.structInfos[baseStructKey]; exists {
+		schema = p.buildStructSchema(baseStructKey)
+	} else if innerContent != "" {
+		schema = &types.Schema{Type: "object"}
+	} else {
+		return nil, &schemaError{code: diagnostic.CodeStructNotFound, message: "未找到结构体 " + baseStructName, ref: baseStructName}
+	}
+
+	// 如果没有内部覆盖内容，直接返回基础结构体
+	if innerContent == "" {
+		return schema, nil
+	}
+
+	// 字段被覆盖后不再等同于基础结构体
+	schema.Ref = ""
+	schema.GoType = responseValue
+
+	// 解析字段覆盖，如 "data=UserInfo, result=user.Info"
+	for _, field := range splitTopLevel(innerContent, ',') {
+		parts := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(parts) != 2 {
+			p.warnf(diagnostic.CodeInvalidResponse, "响应 %s 的字段覆盖 %s 格式错误，应为 字段名=结构体", responseValue, strings.TrimSpace(field))
+			continue
+		}
+
+		node, err := p.buildOverrideSchema(strings.TrimSpace(parts[1]), filePath)
+		if err != nil {
+			p.warnSchemaError(err)
+			continue
+		}
+		replaceSchemaField(schema, strings.TrimSpace(parts[0]), node)
+	}
+
+	return schema, nil
+}
+
+// buildOverrideSchema 构建字段覆盖的结构，支持结构体数组，如 []user.Info
+func (p *Parser) buildOverrideSchema(structName string, filePath string) (*types.Schema, error) {
+	elementName, isArray := strings.CutPrefix(structName, "[]")
+
+	// 解析结构体引用（可能包含包名）
+	structKey, err := p.resolveStructReference(elementName, filePath)
+	if err != nil {
+		// 如果解析失败，尝试直接查找
+		structKey = elementName
+	}
+
+	if _, exists := p.structInfos[structKey]; !exists {
+		return nil, &schemaError{code: diagnostic.CodeStructNotFound, message: "未找到结构体 " + elementName, ref: elementName}
+	}
+
+	// 覆盖的字段位于基础结构体的下一层
+	node := p.buildStructSchemaAt(newSchemaContext(1), structKey)
+	if isArray {
+		return &types.Schema{Type: "array", GoType: structName, Items: node}, nil
+	}
+	return node, nil
+}
+
+// replaceSchemaField 查找并替换对应的字段，没有找到则作为新字段添加
+func replaceSchemaField(schema *types.Schema, fieldName string, node *types.Schema) {
+	node.Name = fieldName
+	for i, child := range schema.Children {
+		if child.Name == fieldName {
+			node.Remark = child.Remark
+			schema.Children[i] = node
+			return
+		}
+	}
+	node.Remark = fmt.Sprintf("%s信息", fieldName)
+	schema.Children = append(schema.Children, node)
+}
+
+// mergeSchema 将 src 的字段合并到 dst，dst 为空时直接返回 src
+func mergeSchema(dst, src *types.Schema) *types.Schema {
+	if dst == nil {
+		return src
+	}
+	dst.Ref = ""
+	dst.Children = append(dst.Children, src.Children...)
+	return dst
+}
+
+// schemaRemark 返回节点在扁平参数中展示的注释，循环引用的节点注明引用的结构体
+func schemaRemark(node *types.Schema) string {
+	element := node
+	for element.Items != nil || element.AdditionalProperties != nil {
+		if element.Items != nil {
+			element = element.Items
+		} else {
+			element = element.AdditionalProperties
+		}
+	}
+	remark := node.Remark
+	if node.Deprecated {
+		remark = types.DeprecatedRemark(remark, node.DeprecatedNote)
+	}
+	if !element.Circular {
+		return remark
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s（循环引用 %s）", remark, types.ShortTypeName(element.Ref)))
+}
+
+// responseParams 将树形结构渲染为扁平的响应参数
+func (p *Parser) responseParams(schema *types.Schema) []types.ResponseParam {
+	var params []types.ResponseParam
+	schema.Walk(func(path string, node *types.Schema) {
+		// 响应参数只展示可选值，不展示校验约束
+		remark := schemaRemark(node)
+		constraints := schemaConstraints(node)
+		if enum := (types.Constraints{Enum: constraints.Enum, EnumRemarks: constraints.EnumRemarks}).Describe(); enum != "" {
+			remark = strings.TrimSpace(fmt.Sprintf("%s（%s）", remark, enum))
+		}
+
+		params = append(params, types.ResponseParam{
+			Name:       path,
+			Type:       node.Type,
+			Required:   node.Required,
+			Remark:     remark,
+			Deprecated: node.Deprecated,
+		})
+	})
+	return params
+}
+
+// requestParams 将树形结构渲染为扁平的请求参数
+func (p *Parser) requestParams(schema *types.Schema) []types.RequestParam {
+	var params []types.RequestParam
+	schema.Walk(func(path string, node *types.Schema) {
+		requireStr := "false"
+		if node.Required {
+			requireStr = "true"
+		}
+
+		// 对于请求体参数，需要从Go类型映射到请求类型，JSON中为字符串的字段（如 string 选项）除外
+		paramType := p.mapGoTypeToRequestType(node.GoType)
+		if node.Type == "string" {
+			paramType = "string"
+		}
+
+		params = append(params, types.RequestParam{
+			Name:        path,
+			Type:        paramType,
+			Require:     requireStr,
+			Remark:      schemaRemark(node),
+			Deprecated:  node.Deprecated,
+			Constraints: schemaConstraints(node),
+		})
+	})
+	return params
+}
+
+// schemaConstraints 返回节点在扁平参数中展示的约束，数组参数同时展示元素的约束
+func schemaConstraints(node *types.Schema) types.Constraints {
+	constraints := node.Constraints
+	for element := node.Items; element != nil; element = element.Items {
+		constraints = constraints.Merge(element.Constraints)
+	}
+	return constraints
+}
@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// intValue 返回整数指针的值，为空时返回 -1
+func intValue(value *int) int {
+	if value == nil {
+		return -1
+	}
+	return *value
+}
+
+func TestFieldTypes(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "fieldtypes")
+	if len(diagnostics) > 0 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+	doc := findDoc(t, docs, "获取条目")
+
+	var paths []string
+	doc.ResponseSchema.Walk(func(path string, node *types.Schema) {
+		paths = append(paths, path)
+	})
+	want := []string{"id", "labels", "tags", "tags.*.name", "meta", "extra", "owner", "owner.name", "points", "points.x", "grid", "matrix"}
+	if !equalNames(paths, want) {
+		t.Errorf("fields = %v, want %v", paths, want)
+	}
+
+	tests := []struct {
+		path     string
+		typ      string
+		minItems int
+		maxItems int
+	}{
+		{"id", "long", -1, -1},
+		{"labels", "object", -1, -1},
+		{"tags", "object", -1, -1},
+		{"meta", "any", -1, -1},
+		{"extra", "any", -1, -1},
+		{"owner", "object", -1, -1},
+		{"points", "array", -1, -1},
+		{"grid", "array", 3, 3},
+		{"matrix", "array", 2, 2},
+	}
+	for _, tt := range tests {
+		node := schemaAt(t, doc.ResponseSchema, tt.path)
+		if node.Type != tt.typ || intValue(node.MinItems) != tt.minItems || intValue(node.MaxItems) != tt.maxItems {
+			t.Errorf("%s = {type: %s, minItems: %d, maxItems: %d}, want %+v", tt.path, node.Type, intValue(node.MinItems), intValue(node.MaxItems), tt)
+		}
+	}
+
+	labels := doc.ResponseSchema.Field("labels")
+	if labels.AdditionalProperties == nil || labels.AdditionalProperties.Type != "string" {
+		t.Errorf("labels additional properties = %+v", labels.AdditionalProperties)
+	}
+	matrix := doc.ResponseSchema.Field("matrix")
+	if inner := matrix.Items; inner == nil || inner.Type != "array" || intValue(inner.MaxItems) != 2 {
+		t.Errorf("matrix items = %+v", inner)
+	}
+}
+
+func TestArrayLengthConstraints(t *testing.T) {
+	tests := []struct {
+		goType   string
+		minItems int
+		maxItems int
+	}{
+		{"[3]int", 3, 3},
+		{"[0]string", 0, 0},
+		{"[]int", -1, -1},
+		{"[Size]int", -1, -1},
+		{"map[string]int", -1, -1},
+	}
+	for _, tt := range tests {
+		expr, err := parseTypeExpr(tt.goType)
+		if err != nil {
+			t.Fatalf("parseTypeExpr(%q) error = %v", tt.goType, err)
+		}
+		c := arrayLengthConstraints(expr)
+		if intValue(c.MinItems) != tt.minItems || intValue(c.MaxItems) != tt.maxItems {
+			t.Errorf("arrayLengthConstraints(%s) = [%d, %d], want [%d, %d]", tt.goType, intValue(c.MinItems), intValue(c.MaxItems), tt.minItems, tt.maxItems)
+		}
+	}
+}
+
+func TestRecursiveStructs(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "recursive")
+	if len(diagnostics) > 0 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+
+	tree := findDoc(t, docs, "获取树")
+	want := []string{"name", "children", "parent"}
+	if got := responseNames(tree.ResponseBody); !equalNames(got, want) {
+		t.Errorf("response names = %v, want %v", got, want)
+	}
+	for _, path := range []string{"children", "parent"} {
+		node := schemaAt(t, tree.ResponseSchema, path)
+		element := node
+		if element.Items != nil {
+			element = element.Items
+		}
+		if !element.Circular || element.Ref != "app.Node" || len(element.Children) > 0 {
+			t.Errorf("%s = %+v, want circular reference to app.Node", path, element)
+		}
+	}
+
+	mutual := findDoc(t, docs, "相互引用")
+	want = []string{"b", "b.a"}
+	if got := responseNames(mutual.ResponseBody); !equalNames(got, want) {
+		t.Errorf("response names = %v, want %v", got, want)
+	}
+	if node := schemaAt(t, mutual.ResponseSchema, "b.a"); !node.Circular {
+		t.Errorf("b.a = %+v, want circular", node)
+	}
+	for _, param := range mutual.ResponseBody {
+		if param.Name == "b.a" && param.Remark != "A（循环引用 app.A）" {
+			t.Errorf("b.a remark = %s", param.Remark)
+		}
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	docs, _ := parseTestdata(t, "schema", func(cfg *config.Config) {
+		cfg.Scan.MaxDepth = 1
+	})
+	doc := findDoc(t, docs, "获取用户")
+
+	address := schemaAt(t, doc.ResponseSchema, "address")
+	if !address.Truncated || len(address.Children) > 0 {
+		t.Errorf("address = %+v, want truncated", address)
+	}
+	want := []string{"id", "name", "address", "friends"}
+	if got := responseNames(doc.ResponseBody); !equalNames(got, want) {
+		t.Errorf("response names = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// appendSecurity 解析 @security 标签中的认证方式名称，多个名称以空格分隔，未定义的名称输出警告并忽略
+func (p *Parser) appendSecurity(names []string, value string) []string {
+	for _, name := range strings.Fields(value) {
+		if _, exists := p.security.Schemes[name]; !exists && name != config.SecurityNone {
+			p.warnf(diagnostic.CodeUnknownSecurity, "认证方式 %s 未在配置文件的 security.schemes 中定义，已忽略", name)
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// resolveSecurity 返回接口的认证方式，没有声明 @security 时使用默认的认证方式，声明了 none 时不需要认证
+func (p *Parser) resolveSecurity(names []string, declared bool) []types.SecurityScheme {
+	if !declared {
+		names = p.security.Default
+	}
+
+	var schemes []types.SecurityScheme
+	for _, name := range names {
+		if name == config.SecurityNone {
+			return nil
+		}
+		scheme := p.security.Schemes[name]
+		schemes = append(schemes, types.SecurityScheme{
+			Name:         name,
+			Type:         scheme.Type,
+			In:           scheme.In,
+			ParamName:    scheme.Name,
+			BearerFormat: scheme.BearerFormat,
+			Description:  scheme.Description,
+		})
+	}
+	return schemes
+}
+
+// swagSecurityNames 返回 swag 的 @Security 标签中的认证方式名称，忽略 OAuth2 的作用域和 || 等连接符
+// 如 OAuth2Application[write, admin] || ApiKeyAuth
+func swagSecurityNames(value string) string {
+	var names []string
+	depth := 0
+	var name strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(name.String()); text != "" && text != "||" && text != "&&" {
+			names = append(names, text)
+		}
+		name.Reset()
+	}
+	for _, r := range value {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth > 0:
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			name.WriteRune(r)
+		}
+	}
+	flush()
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+func TestSecurity(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "security")
+
+	tests := []struct {
+		title   string
+		schemes []string
+	}{
+		{"默认认证", []string{"bearer"}},
+		{"不需要认证", nil},
+		{"多个认证方式", []string{"apikey", "session", "token"}},
+		{"未定义的认证方式", nil},
+	}
+	for _, tt := range tests {
+		doc := findDoc(t, docs, tt.title)
+		var names []string
+		for _, scheme := range doc.Security {
+			names = append(names, scheme.Name)
+		}
+		if !equalNames(names, tt.schemes) {
+			t.Errorf("%s security = %v, want %v", tt.title, names, tt.schemes)
+		}
+	}
+
+	// 认证方式的配置随接口一起输出，apiKey 默认在请求头中
+	want := []types.SecurityScheme{
+		{Name: "apikey", Type: "apiKey", In: "header", ParamName: "X-API-Key"},
+		{Name: "session", Type: "cookie", ParamName: "SESSIONID"},
+		{Name: "token", Type: "apiKey", In: "query", ParamName: "token"},
+	}
+	if got := findDoc(t, docs, "多个认证方式").Security; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("security = %+v, want %+v", got, want)
+	}
+	if got := findDoc(t, docs, "默认认证").Security[0]; got.BearerFormat != "JWT" || got.Description != "登录令牌" {
+		t.Errorf("bearer = %+v", got)
+	}
+
+	if len(diagnostics) != 1 || diagnostics[0].Code != diagnostic.CodeUnknownSecurity {
+		t.Errorf("diagnostics = %v", diagnostics)
+	}
+}
+
+func TestSwagSecurityNames(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"ApiKeyAuth", "ApiKeyAuth"},
+		{"OAuth2Application[write, admin]", "OAuth2Application"},
+		{"OAuth2Application[write] || ApiKeyAuth", "OAuth2Application ApiKeyAuth"},
+		{"ApiKeyAuth && BasicAuth", "ApiKeyAuth BasicAuth"},
+	}
+	for _, tt := range tests {
+		if got := swagSecurityNames(tt.value); got != tt.want {
+			t.Errorf("swagSecurityNames(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
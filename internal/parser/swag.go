@@ -0,0 +1,353 @@
+package parser
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// swagParamTypes swag 参数类型对应的请求参数类型
+var swagParamTypes = map[string]string{
+	"string":  "string",
+	"integer": "int",
+	"number":  "double",
+	"boolean": "boolean",
+	"file":    "file",
+	"array":   "array",
+	"object":  "object",
+}
+
+// swagResponseTypes swag 响应类型对应的响应参数类型
+var swagResponseTypes = map[string]string{
+	"string":  "string",
+	"integer": "int",
+	"number":  "number",
+	"boolean": "boolean",
+	"array":   "array",
+	"object":  "object",
+}
+
+// swagTags swag 的函数注释标签，与 runapi 注释写在一起时不作为未知标签
+var swagTags = map[string]bool{
+	"@summary":              true,
+	"@description":          true,
+	"@description.markdown": true,
+	"@id":                   true,
+	"@tags":                 true,
+	"@accept":               true,
+	"@produce":              true,
+	"@param":                true,
+	"@security":             true,
+	"@success":              true,
+	"@failure":              true,
+	"@response":             true,
+	"@header":               true,
+	"@router":               true,
+	"@deprecatedrouter":     true,
+	"@deprecated":           true,
+	"@codesamples":          true,
+	"@state":                true,
+}
+
+// isSwagTag 检查是否为 swag 的标签，不区分大小写，包括 @x- 开头的扩展标签
+func isSwagTag(tag string) bool {
+	tag = strings.ToLower(tag)
+	return swagTags[tag] || strings.HasPrefix(tag, "@x-")
+}
+
+// isSwagDoc 检查函数注释是否为 swag 注释，以是否包含 @Router 为准
+func isSwagDoc(doc *ast.CommentGroup) bool {
+	for _, line := range docLines(doc) {
+		if tag, _, _ := strings.Cut(strings.TrimSpace(line), " "); strings.EqualFold(tag, "@router") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSwagDoc 解析 swaggo/swag 格式的函数注释，标签不区分大小写
+// 支持 @Summary、@Description、@Tags、@Param、@Success、@Failure、@Header、@Router、@Security 和 @Deprecated
+func (p *Parser) parseSwagDoc(doc *ast.CommentGroup, filePath string) (*types.APIDoc, error) {
+	apiDoc := &types.APIDoc{}
+	hasSuccess := false
+	var security []string
+	securityDeclared := false
+
+	for _, line := range commentLines(doc) {
+		p.pos = line.pos
+		tag, value, _ := strings.Cut(strings.TrimSpace(line.text), " ")
+		value = strings.TrimSpace(value)
+		if strings.EqualFold(tag, "@deprecated") {
+			apiDoc.Deprecated = true
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		switch strings.ToLower(tag) {
+		case "@summary":
+			apiDoc.Title = value
+		case "@description":
+			apiDoc.Description = appendLine(apiDoc.Description, value)
+		case "@tags":
+			// ShowDoc 的目录只能有一个，使用第一个标签
+			catalog, _, _ := strings.Cut(value, ",")
+			apiDoc.Catalog = strings.TrimSpace(catalog)
+		case "@param":
+			p.parseSwagParam(apiDoc, value, filePath)
+		case "@success":
+			// 只使用第一个成功响应
+			if !hasSuccess {
+				hasSuccess = p.parseSwagSuccess(apiDoc, value, filePath)
+			}
+		case "@failure":
+			p.parseSwagFailure(apiDoc, value, filePath)
+		case "@header":
+			p.parseSwagHeader(apiDoc, value)
+		case "@security":
+			securityDeclared = true
+			security = p.appendSecurity(security, swagSecurityNames(value))
+		case "@router":
+			fields := strings.Fields(value)
+			apiDoc.Router = fields[0]
+			if len(fields) > 1 {
+				apiDoc.Method = strings.ToLower(strings.Trim(fields[1], "[]"))
+			}
+		}
+	}
+
+	apiDoc.Security = p.resolveSecurity(security, securityDeclared)
+	p.applyParamGroups(apiDoc, nil, nil)
+
+	// 没有 @Summary 时使用描述的第一行作为标题
+	if apiDoc.Title == "" {
+		apiDoc.Title, _, _ = strings.Cut(apiDoc.Description, "\n")
+	}
+
+	return apiDoc, nil
+}
+
+// parseSwagParam 解析 @Param 注释，格式为 名称 位置 类型 是否必传 "描述" [属性]
+// body 参数和类型为结构体的 query/header/path/formData 参数会展开结构体
+func (p *Parser) parseSwagParam(apiDoc *types.APIDoc, value, filePath string) {
+	fields := swagFields(value)
+	if len(fields) < 4 {
+		p.warnf(diagnostic.CodeInvalidParam, "@Param 格式错误: %s", value)
+		return
+	}
+
+	name, location, paramType, required := fields[0], fields[1], fields[2], fields[3]
+	remark := ""
+	if len(fields) > 4 {
+		remark = fields[4]
+	}
+
+	if location == "body" {
+		p.parseSwagBody(apiDoc, paramType, filePath)
+		return
+	}
+
+	mappedType, exists := swagParamTypes[paramType]
+	if !exists {
+		mappedType = p.mapGoTypeToRequestType(p.resolveNamedTypes("", filePath, paramType))
+	}
+	if mappedType == "object" {
+		p.parseBindingParams(apiDoc, location, paramType, filePath)
+		return
+	}
+	// 路径参数始终必传
+	if location == "path" {
+		required = "true"
+	}
+
+	param := types.RequestParam{
+		Name:    name,
+		Type:    mappedType,
+		Require: required,
+		Remark:  remark,
+	}
+	if len(fields) > 5 {
+		param.Constraints = swagAttributes(fields[5:])
+	}
+
+	switch location {
+	case "path":
+		apiDoc.Path = append(apiDoc.Path, param)
+	case "header":
+		apiDoc.Header = append(apiDoc.Header, param)
+	case "query":
+		apiDoc.Query = append(apiDoc.Query, param)
+	case "formData":
+		apiDoc.FormData = append(apiDoc.FormData, param)
+	}
+}
+
+// parseSwagBody 解析 body 参数，[]Type 表示请求体为结构体数组，参数说明使用元素的字段
+func (p *Parser) parseSwagBody(apiDoc *types.APIDoc, bodyType, filePath string) {
+	elementType, isArray := strings.CutPrefix(bodyType, "[]")
+	p.parseRequestBody(apiDoc, elementType, filePath)
+	if isArray && apiDoc.BodySchema != nil {
+		apiDoc.BodySchema = &types.Schema{Type: "array", GoType: bodyType, Items: apiDoc.BodySchema}
+	}
+}
+
+// parseSwagSuccess 解析 @Success 注释，格式为 状态码 {类型} 结构体 "描述"，返回是否解析到响应结构
+func (p *Parser) parseSwagSuccess(apiDoc *types.APIDoc, value, filePath string) bool {
+	fields := swagFields(value)
+	if len(fields) < 3 {
+		return false
+	}
+
+	switch fields[1] {
+	case "{object}":
+		p.parseResponseBody(apiDoc, fields[2], filePath, false)
+	case "{array}":
+		// 数组响应的参数说明使用元素的字段
+		schema, err := p.buildResponseSchema(fields[2], filePath)
+		if err != nil {
+			p.warnSchemaError(err)
+			return false
+		}
+		apiDoc.ResponseSchema = &types.Schema{Type: "array", GoType: "[]" + fields[2], Items: schema}
+		apiDoc.ResponseBody = append(apiDoc.ResponseBody, p.responseParams(schema)...)
+	default:
+		return false
+	}
+	return apiDoc.ResponseSchema != nil
+}
+
+// parseSwagFailure 解析 @Failure 注释，转换为 @failure 的格式，状态码 default 为未指定状态码的失败响应
+func (p *Parser) parseSwagFailure(apiDoc *types.APIDoc, value, filePath string) {
+	fields := swagFields(value)
+	status := fields[0]
+	if strings.EqualFold(status, "default") {
+		status = "0"
+	}
+
+	var parts []string
+	switch {
+	case len(fields) >= 3 && (fields[1] == "{object}" || fields[1] == "{array}"):
+		parts = append(parts, fields[2])
+		fields = fields[3:]
+	case len(fields) >= 2 && strings.HasPrefix(fields[1], "{"):
+		// 基本类型的失败响应只保留描述
+		fields = fields[2:]
+		if len(fields) > 0 {
+			fields = fields[1:]
+		}
+	default:
+		fields = fields[1:]
+	}
+	parts = append(parts, fields...)
+
+	p.parseFailure(apiDoc, strings.TrimSpace(status+" "+strings.Join(parts, " ")), filePath, false)
+}
+
+// parseSwagHeader 解析 @Header 注释，格式为 状态码 {类型} 名称 "描述"，作为响应头
+func (p *Parser) parseSwagHeader(apiDoc *types.APIDoc, value string) {
+	fields := swagFields(value)
+	if len(fields) < 3 {
+		return
+	}
+
+	headerType := strings.Trim(fields[1], "{}")
+	mappedType, exists := swagResponseTypes[headerType]
+	if !exists {
+		mappedType = p.mapGoTypeToResponseType(headerType)
+	}
+	param := types.ResponseParam{Name: fields[2], Type: mappedType}
+	if len(fields) > 3 {
+		param.Remark = fields[3]
+	}
+
+	for _, existing := range apiDoc.ResponseHeader {
+		if existing.Name == param.Name {
+			return
+		}
+	}
+	apiDoc.ResponseHeader = append(apiDoc.ResponseHeader, param)
+}
+
+// swagFields 按空白分割 swag 注释的值，双引号内和括号内的空白不分割，双引号会被去掉
+func swagFields(value string) []string {
+	var (
+		fields  []string
+		current strings.Builder
+		quoted  bool
+		depth   int
+		started bool
+	)
+	flush := func() {
+		if started {
+			fields = append(fields, current.String())
+		}
+		current.Reset()
+		started = false
+	}
+
+	for _, r := range value {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case quoted:
+			current.WriteRune(r)
+		case r == '(':
+			depth++
+			current.WriteRune(r)
+			started = true
+		case r == ')':
+			depth--
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && depth <= 0:
+			flush()
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	flush()
+	return fields
+}
+
+// swagAttributes 将 @Param 的属性转换为校验约束，如 enums(a,b)、minimum(1)、maxlength(20)、format(email)
+func swagAttributes(attributes []string) types.Constraints {
+	var c types.Constraints
+	for _, attribute := range attributes {
+		name, arg, ok := strings.Cut(attribute, "(")
+		if !ok {
+			continue
+		}
+		arg = strings.TrimSpace(strings.TrimSuffix(arg, ")"))
+
+		switch strings.ToLower(name) {
+		case "enums":
+			for _, enum := range strings.Split(arg, ",") {
+				c.Enum = append(c.Enum, strings.TrimSpace(enum))
+			}
+		case "minimum":
+			if value, err := strconv.ParseFloat(arg, 64); err == nil {
+				c.Minimum = &value
+			}
+		case "maximum":
+			if value, err := strconv.ParseFloat(arg, 64); err == nil {
+				c.Maximum = &value
+			}
+		case "minlength":
+			if value, err := strconv.Atoi(arg); err == nil {
+				c.MinLength = &value
+			}
+		case "maxlength":
+			if value, err := strconv.Atoi(arg); err == nil {
+				c.MaxLength = &value
+			}
+		case "format":
+			c.Format = arg
+		}
+	}
+	return c
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+func TestSwagFields(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{`id path int true "用户ID"`, []string{"id", "path", "int", "true", "用户ID"}},
+		{`role query string false "角色 名称" enums(admin, user)`, []string{"role", "query", "string", "false", "角色 名称", "enums(admin, user)"}},
+		{`200 {object} model.User ""`, []string{"200", "{object}", "model.User", ""}},
+	}
+	for _, tt := range tests {
+		if got := swagFields(tt.value); !equalNames(got, tt.want) {
+			t.Errorf("swagFields(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSwagAttributes(t *testing.T) {
+	c := swagAttributes([]string{"enums(a, b)", "minimum(1)", "maximum(10)", "minlength(2)", "maxlength(20)", "format(email)", "default(a)"})
+	if got := c.Describe(); got != "取值 1~10，长度 2~20，可选值 a/b，格式 email" {
+		t.Errorf("swagAttributes() = %q", got)
+	}
+}
+
+func TestSwagDialect(t *testing.T) {
+	tests := []struct {
+		dialect string
+		titles  []string
+	}{
+		{config.DialectRunAPI, []string{"健康检查"}},
+		{config.DialectSwag, []string{"创建用户", "查询用户列表", "健康检查"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			docs, diagnostics := parseTestdata(t, "swag", func(cfg *config.Config) {
+				cfg.Scan.Dialect = tt.dialect
+			})
+			if len(diagnostics) > 0 {
+				t.Fatalf("diagnostics = %v", diagnostics)
+			}
+			var titles []string
+			for _, doc := range docs {
+				titles = append(titles, doc.Title)
+			}
+			if !equalNames(titles, tt.titles) {
+				t.Errorf("titles = %v, want %v", titles, tt.titles)
+			}
+		})
+	}
+}
+
+func TestSwagDoc(t *testing.T) {
+	docs, _ := parseTestdata(t, "swag")
+
+	create := findDoc(t, docs, "创建用户")
+	if create.Catalog != "用户管理" || create.Description != "创建新用户" || create.Method != "post" || create.Router != "/users/{id}" {
+		t.Errorf("create = {catalog: %s, description: %s, method: %s, router: %s}", create.Catalog, create.Description, create.Method, create.Router)
+	}
+	params := []struct {
+		location string
+		params   []types.RequestParam
+		require  string
+		describe string
+	}{
+		{"path", create.Path, "true", "取值 ≥1"},
+		{"query", create.Query, "false", "可选值 admin/user"},
+		{"header", create.Header, "false", "长度 ≤32"},
+	}
+	for _, tt := range params {
+		if len(tt.params) != 1 {
+			t.Errorf("%s params = %+v", tt.location, tt.params)
+			continue
+		}
+		if param := tt.params[0]; param.Require != tt.require || param.Constraints.Describe() != tt.describe {
+			t.Errorf("%s param = %+v, want {require: %s, constraints: %s}", tt.location, param, tt.require, tt.describe)
+		}
+	}
+	if got := requestNames(create.Body); !equalNames(got, []string{"name"}) {
+		t.Errorf("body = %v", got)
+	}
+	// 只使用第一个 @Success
+	if got := responseNames(create.ResponseBody); !equalNames(got, []string{"id", "name"}) {
+		t.Errorf("response = %v", got)
+	}
+	if len(create.ResponseHeader) != 1 || create.ResponseHeader[0].Name != "X-Request-Id" {
+		t.Errorf("response header = %+v", create.ResponseHeader)
+	}
+	failures := []struct {
+		status      int
+		description string
+		hasSchema   bool
+	}{
+		{400, "参数错误", true},
+		{500, "服务错误", false},
+		{0, "", true},
+	}
+	if len(create.Failures) != len(failures) {
+		t.Fatalf("failures = %+v", create.Failures)
+	}
+	for i, tt := range failures {
+		failure := create.Failures[i]
+		if failure.Status != tt.status || failure.Description != tt.description || (failure.Schema != nil) != tt.hasSchema {
+			t.Errorf("failure %d = %+v, want %+v", i, failure, tt)
+		}
+	}
+	if len(create.Security) != 1 || create.Security[0].ParamName != "X-API-Key" {
+		t.Errorf("security = %+v", create.Security)
+	}
+
+	// 没有 @Summary 时使用描述作为标题，{array} 响应的参数说明使用元素的字段
+	list := findDoc(t, docs, "查询用户列表")
+	if !list.Deprecated || list.ResponseSchema.Type != "array" || list.ResponseSchema.Items.Ref == "" {
+		t.Errorf("list = {deprecated: %t, response: %+v}", list.Deprecated, list.ResponseSchema)
+	}
+	if got := requestNames(list.Query); !equalNames(got, []string{"keyword"}) {
+		t.Errorf("query = %v", got)
+	}
+}
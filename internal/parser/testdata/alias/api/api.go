@@ -0,0 +1,20 @@
+package api
+
+import (
+	m "example.com/app/model"
+)
+
+// Detail 详情
+type Detail struct {
+	Info  m.Info   `json:"info"`  // 用户信息
+	Infos []m.Info `json:"infos"` // 用户信息列表
+}
+
+// GetDetail 获取详情
+// runapi
+// @catalog 用户
+// @title 获取详情
+// @method get
+// @url /detail
+// @response_body Detail
+func GetDetail() {}
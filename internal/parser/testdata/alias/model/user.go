@@ -0,0 +1,6 @@
+package model
+
+// Info 用户信息
+type Info struct {
+	ID int64 `json:"id"` // ID
+}
@@ -0,0 +1,64 @@
+package app
+
+import "mime/multipart"
+
+// Paging 分页参数
+type Paging struct {
+	Page int `form:"page"` // 页码
+	Size int `form:"size"` // 每页数量
+}
+
+// ListRequest 列表请求
+type ListRequest struct {
+	Paging
+	Keyword string `form:"keyword" binding:"required"` // 关键字
+	Status  string `query:"status"`                    // 状态
+	Sort    string // 排序
+	Skip    string `form:"-"` // 忽略
+}
+
+// AuthHeader 认证请求头
+type AuthHeader struct {
+	Token string `header:"X-Token" binding:"required"` // 令牌
+}
+
+// IDRequest 路径参数
+type IDRequest struct {
+	ID int64 `json:"-" uri:"id"` // ID
+}
+
+// UploadRequest 上传请求
+type UploadRequest struct {
+	File *multipart.FileHeader `form:"file" binding:"required"` // 文件
+	Name string                `form:"name"`                    // 名称
+}
+
+// Item 条目
+type Item struct {
+	ID     int64  `json:"-" uri:"id"`                           // ID
+	Name   string `json:"name" binding:"required"`              // 名称
+	Note   string `json:"note" binding:"max=10"`                // 备注
+	Remark string `json:"remark,omitempty" validate:"required"` // 说明
+}
+
+// Update 更新条目
+// runapi
+// @catalog 条目
+// @title 更新条目
+// @method put
+// @url /items/{id}
+// @query ListRequest
+// @header AuthHeader
+// @uri IDRequest
+// @body Item
+// @response_body Item
+func Update() {}
+
+// Upload 上传文件
+// runapi
+// @catalog 条目
+// @title 上传文件
+// @method post
+// @url /upload
+// @form UploadRequest
+func Upload() {}
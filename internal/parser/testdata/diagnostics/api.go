@@ -0,0 +1,51 @@
+package app
+
+// UserInfo 用户信息
+type UserInfo struct {
+	Name string `json:"name"` // 名称
+}
+
+// Response 统一响应
+type Response struct {
+	Data any `json:"data"` // 数据
+}
+
+// GetUser 获取用户
+// runapi
+// @catalog 用户
+// @title 获取用户
+// @titel 获取用户
+// @method get
+// @url /user
+// @param id query int
+// @param name cookie string true 名称
+// @Summary swag 标签不会报告
+// @remark
+// @response_body UserInfo
+func GetUser() {}
+
+// UpdateUser 更新用户
+// runapi
+// @catalog 用户
+// @title 更新用户
+// @method fetch
+// @url /user
+// @body UserInf
+// @response_body Response{data=UserInfo
+func UpdateUser() {}
+
+// DeleteUser 删除用户
+// runapi
+// @catalog 用户
+// @method delete
+// @url /user
+func DeleteUser() {}
+
+// GetAccount 获取账号
+// runapi
+// @catalog 用户
+// @title 获取账号
+// @method get
+// @url /account
+// @response_body model.Account
+func GetAccount() {}
@@ -0,0 +1,6 @@
+package dto
+
+// Account 账号
+type Account struct {
+	Name string `json:"name"` // 名称
+}
@@ -0,0 +1,83 @@
+package app
+
+// Base 基础字段
+type Base struct {
+	ID        int64  `json:"id"`         // ID
+	CreatedAt string `json:"created_at"` // 创建时间
+	Name      string `json:"name"`       // 基础名称
+}
+
+// meta 未导出的元数据
+type meta struct {
+	Version int    `json:"version"` // 版本
+	Note    string `json:"note"`    // 备注
+}
+
+// Owner 所有者
+type Owner struct {
+	UID int64 `json:"uid"` // 用户ID
+}
+
+// Status 状态
+type Status int
+
+// level 等级
+type level int
+
+// Audit 审计字段
+type Audit struct {
+	Note   string `json:"note"`   // 审计备注
+	Editor string `json:"editor"` // 编辑人
+}
+
+// Trace 追踪字段
+type Trace struct {
+	Editor string // 编辑人
+	Span   string `json:"span"` // 跨度
+}
+
+// Extra 额外字段
+type Extra struct {
+	Span string `json:"span"` // 跨度
+}
+
+// Tagged 带标签的冲突字段
+type Tagged struct {
+	Label string `json:"Label"` // 带标签的名称
+}
+
+// Untagged 不带标签的冲突字段
+type Untagged struct {
+	Label string // 不带标签的名称
+}
+
+// Node 自引用节点
+type Node struct {
+	*Node
+	Value string `json:"value"` // 值
+}
+
+// Page 分页
+type Page struct {
+	Base
+	*meta
+	Owner `json:"owner"`
+	Status
+	level
+	Audit
+	Trace
+	Extra
+	Tagged
+	Untagged
+	Name string `json:"name"` // 名称
+	Tree Node   `json:"tree"` // 树
+}
+
+// GetPage 获取分页
+// runapi
+// @catalog 分页
+// @title 获取分页
+// @method get
+// @url /page
+// @response_body Page
+func GetPage() {}
@@ -0,0 +1,13 @@
+package api
+
+import "example.com/app/model"
+
+// GetTask 获取任务
+// runapi
+// @catalog 任务
+// @title 获取任务
+// @method get
+// @url /task
+// @query model.TaskQuery
+// @response_body model.Task
+func GetTask(query model.TaskQuery) model.Task { return model.Task{} }
@@ -0,0 +1,23 @@
+package api
+
+import (
+	m "example.com/app/model"
+	other "example.com/app/other/model"
+)
+
+// TaskView 任务视图，通过导入别名引用命名类型
+type TaskView struct {
+	Status m.Status     `json:"status"` // 状态
+	Phase  other.Status `json:"phase"`  // 阶段
+	Levels []m.Level    `json:"levels"` // 等级
+}
+
+// GetTaskView 获取任务视图
+// runapi
+// @catalog 任务
+// @title 获取任务视图
+// @method get
+// @url /task/view
+// @param phase query other.Status false 阶段
+// @response_body TaskView
+func GetTaskView(phase other.Status) TaskView { return TaskView{Phase: phase} }
@@ -0,0 +1,15 @@
+package model
+
+// 常量先于类型声明出现
+const (
+	LevelLow  Level = "low"  // 低
+	LevelHigh Level = "high" // 高
+)
+
+const (
+	KindA, KindB Kind = iota * 10, iota*10 + 1 // 类型
+	KindC, KindD                               // 其他类型
+)
+
+// Priority 优先级
+const Priority = Level("mid")
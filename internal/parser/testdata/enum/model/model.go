@@ -0,0 +1,33 @@
+package model
+
+// Status 状态
+type Status int
+
+const (
+	StatusUnknown  Status = iota // 未知
+	StatusActive                 // 启用
+	StatusDisabled               // 禁用
+	_
+	StatusDeleted // 已删除
+)
+
+// Level 等级
+type Level string
+
+// Kind 类型
+type Kind uint8
+
+// Task 任务
+type Task struct {
+	Status Status  `json:"status"`               // 状态
+	Level  Level   `json:"level"`                // 等级
+	Kinds  []Kind  `json:"kinds"`                // 类型
+	Mode   Status  `json:"mode" enums:"1,2"`     // 模式
+	Next   *Status `json:"next,omitempty"`       // 下一个状态
+	Filter Level   `json:"filter" form:"filter"` // 过滤
+}
+
+// TaskQuery 查询条件
+type TaskQuery struct {
+	Status Status `form:"status"` // 状态
+}
@@ -0,0 +1,9 @@
+package model
+
+// Status 发布状态，与 example.com/app/model.Status 同名
+type Status string
+
+const (
+	StatusDraft     Status = "draft"     // 草稿
+	StatusPublished Status = "published" // 已发布
+)
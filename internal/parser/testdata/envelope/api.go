@@ -0,0 +1,93 @@
+package app
+
+import (
+	"example.com/app/response"
+	"example.com/app/user"
+)
+
+// Get 获取用户
+// runapi
+// @catalog 用户
+// @title 获取用户
+// @method get
+// @url /users/{id}
+// @param id path int true 用户ID
+// @response_body user.Info
+func Get(id int64) user.Info { return user.Info{ID: id} }
+
+// List 用户列表
+// runapi
+// @catalog 用户
+// @title 用户列表
+// @method get
+// @url /users
+// @data []user.Info
+func List() []user.Info { return nil }
+
+// Detail 用户详情
+// runapi
+// @catalog 用户
+// @title 已包装的响应
+// @method get
+// @url /users/{id}/detail
+// @param id path int true 用户ID
+// @response_body response.Response{data=user.Info}
+func Detail() response.Response { return response.Response{} }
+
+// Create 创建用户
+// runapi
+// @catalog 用户
+// @title 创建用户
+// @method post
+// @url /users
+// @response_body user.Info
+// @failure 400 response.Error 参数错误
+// @failure 404 未找到
+func Create() (user.Info, *response.Error) { return user.Info{}, nil }
+
+// Export 导出用户
+// runapi
+// @catalog 用户
+// @title 导出用户
+// @method get
+// @url /users/export
+// @response_body user.Info
+// @failure 404 未找到
+// @raw
+func Export() {}
+
+// Page 用户分页
+// runapi
+// @catalog 用户
+// @title 用户分页
+// @method get
+// @url /users/page
+// @response_body response.Page[user.Info]
+func Page() response.Page[user.Info] { return response.Page[user.Info]{} }
+
+// Search 搜索用户
+// runapi
+// @catalog 用户
+// @title 搜索用户
+// @method get
+// @url /users/search
+// @response_body response.List{items=[]user.Info}
+func Search() response.List { return response.List{} }
+
+// Count 用户数量
+// runapi
+// @catalog 用户
+// @title 用户数量
+// @method get
+// @url /users/count
+// @response_body int64
+func Count() int64 { return 0 }
+
+// Tags 用户标签
+// runapi
+// @catalog 用户
+// @title 用户标签
+// @method get
+// @url /users/tags
+// @response_body map[string][]user.Info
+func Tags() map[string][]user.Info { return nil }
@@ -0,0 +1,25 @@
+package response
+
+// Response 统一响应
+type Response struct {
+	Code    int    `json:"code"`    // 状态码
+	Message string `json:"message"` // 提示信息
+	Data    any    `json:"data"`    // 业务数据
+}
+
+// Error 错误详情
+type Error struct {
+	Field string `json:"field"` // 出错的字段
+}
+
+// Page 分页数据
+type Page[T any] struct {
+	Total int64 `json:"total"` // 总数
+	Items []T   `json:"items"` // 列表
+}
+
+// List 列表数据
+type List struct {
+	Total int64 `json:"total"` // 总数
+	Items any   `json:"items"` // 列表
+}
@@ -0,0 +1,7 @@
+package user
+
+// Info 用户信息
+type Info struct {
+	ID   int64  `json:"id"`   // 用户ID
+	Name string `json:"name"` // 用户名
+}
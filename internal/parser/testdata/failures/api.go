@@ -0,0 +1,27 @@
+package app
+
+import "example.com/app/response"
+
+// Create 创建订单
+// runapi
+// @catalog 订单
+// @title 创建订单
+// @method post
+// @url /orders
+// @failure 400 response.Error 参数错误
+// @failure 400 response.Response{data=[]response.FieldError} 字段校验失败
+// @failure 404 未找到
+// @response_fail_body response.Error
+func Create() {}
+
+// Delete 删除订单
+// runapi
+// @catalog 订单
+// @title 删除订单
+// @method delete
+// @url /orders
+// @failure abc response.Error
+// @failure 500 response.Missing 服务错误
+func Delete() {}
+
+var _ = response.Error{}
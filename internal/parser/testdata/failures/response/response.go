@@ -0,0 +1,17 @@
+package response
+
+// Response 通用响应
+type Response struct {
+	Code int `json:"code"` // 状态码
+	Data any `json:"data"` // 数据
+}
+
+// Error 错误
+type Error struct {
+	Message string `json:"message"` // 错误信息
+}
+
+// FieldError 字段错误
+type FieldError struct {
+	Field string `json:"field"` // 字段
+}
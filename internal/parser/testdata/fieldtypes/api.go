@@ -0,0 +1,39 @@
+package app
+
+// Tag 标签
+type Tag struct {
+	Name string `json:"name"` // 名称
+}
+
+// Base 基础字段
+type Base struct {
+	ID int64 `json:"id"` // ID
+}
+
+// Item 条目
+type Item struct {
+	*Base
+	Labels map[string]string `json:"labels"` // 标签
+	Tags   map[string]Tag    `json:"tags"`   // 标签对象
+	Meta   interface{}       `json:"meta"`   // 元数据
+	Extra  any               `json:"extra"`  // 扩展
+	Owner  struct {
+		Name string `json:"name"` // 名称
+	} `json:"owner"` // 所有者
+	Points []struct {
+		X int `json:"x"` // 横坐标
+	} `json:"points"` // 坐标
+	Grid   [3]int      `json:"grid"`   // 网格
+	Matrix [2][2]int   `json:"matrix"` // 矩阵
+	Events chan string `json:"events"` // 不可序列化
+	Hook   func()      `json:"hook"`   // 不可序列化
+}
+
+// Get 获取条目
+// runapi
+// @catalog 条目
+// @title 获取条目
+// @method get
+// @url /item
+// @response_body Item
+func Get() {}
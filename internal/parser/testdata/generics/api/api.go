@@ -0,0 +1,59 @@
+package api
+
+import (
+	"example.com/app/response"
+	"example.com/app/user"
+)
+
+// UserPage 用户分页
+type UserPage = response.Page[user.User]
+
+// List 用户列表
+// runapi
+// @catalog 用户
+// @title 用户列表
+// @method get
+// @url /users
+// @response_body response.Result[response.Page[user.User]]
+func List() {}
+
+// Save 保存用户
+// runapi
+// @catalog 用户
+// @title 保存用户
+// @method post
+// @url /users
+// @body response.Pair[string, user.User]
+func Save() {}
+
+// Page 用户分页
+// runapi
+// @catalog 用户
+// @title 用户分页
+// @method get
+// @url /users/page
+// @response_body UserPage
+func Page() {}
+
+// GetStatus 用户状态
+// runapi
+// @catalog 用户
+// @title 用户状态
+// @method get
+// @url /users/status
+// @response_body response.Result[user.Status]
+func GetStatus() {}
+
+// StatusView 状态视图
+type StatusView struct {
+	Current response.Result[user.Status] `json:"current"` // 当前状态
+}
+
+// GetStatusView 状态视图
+// runapi
+// @catalog 用户
+// @title 状态视图
+// @method get
+// @url /users/status/view
+// @response_body StatusView
+func GetStatusView() {}
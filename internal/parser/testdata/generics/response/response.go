@@ -0,0 +1,19 @@
+package response
+
+// Result 通用响应
+type Result[T any] struct {
+	Code int `json:"code"` // 状态码
+	Data T   `json:"data"` // 数据
+}
+
+// Page 分页
+type Page[T any] struct {
+	Total int64 `json:"total"` // 总数
+	List  []T   `json:"list"`  // 列表
+}
+
+// Pair 键值对
+type Pair[K comparable, V any] struct {
+	Key   K `json:"key"`   // 键
+	Value V `json:"value"` // 值
+}
@@ -0,0 +1,15 @@
+package user
+
+// User 用户
+type User struct {
+	ID   int64  `json:"id"`   // ID
+	Name string `json:"name"` // 名称
+}
+
+// Status 用户状态
+type Status int
+
+const (
+	StatusActive Status = 1 // 正常
+	StatusBanned Status = 2 // 封禁
+)
@@ -0,0 +1,94 @@
+package app
+
+import "net/http"
+
+// Service 用户服务
+type Service interface{}
+
+// Controller 用户控制器
+type Controller struct{}
+
+// Show 用户详情
+// runapi
+// @catalog 用户
+// @title 方法
+// @method get
+// @url /users/show
+func (c *Controller) Show(w http.ResponseWriter, r *http.Request) {}
+
+// ListUsers 返回用户列表的处理函数
+func ListUsers(svc Service) http.HandlerFunc {
+	// runapi
+	// @catalog 用户
+	// @title 处理函数工厂
+	return func(w http.ResponseWriter, r *http.Request) {}
+}
+
+// runapi
+// @catalog 用户
+// @title 变量声明
+// @method get
+// @url /users/{id}
+// @param id path int true 用户ID
+var GetUser = func(w http.ResponseWriter, r *http.Request) {}
+
+var (
+	// runapi
+	// @catalog 用户
+	// @title 分组变量声明
+	// @method get
+	// @url /users/export
+	ExportUsers = func(w http.ResponseWriter, r *http.Request) {}
+
+	// Version 版本号，不是接口
+	Version = "1.0"
+)
+
+// Route 路由表中的一项
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+}
+
+var routes = []Route{
+	{
+		// runapi
+		// @catalog 用户
+		// @title 路由表
+		Method:  "POST",
+		Path:    "/users",
+		Handler: CreateUser,
+	},
+}
+
+// CreateUser 创建用户
+func CreateUser(w http.ResponseWriter, r *http.Request) {}
+
+// Setup 注册路由
+func Setup(mux *http.ServeMux) {
+	// runapi
+	// @catalog 用户
+	// @title 赋值语句
+	// @method put
+	// @url /users/update
+	update := func(w http.ResponseWriter, r *http.Request) {}
+	mux.HandleFunc("PUT /users/update", update)
+	mux.HandleFunc("GET /users", ListUsers(nil))
+
+	// runapi
+	// @catalog 用户
+	// @title 路由注册语句
+	// @method delete
+	// @url /users/delete
+	mux.HandleFunc("DELETE /users/delete", func(w http.ResponseWriter, r *http.Request) {})
+
+	mux.HandleFunc("/admin/", func(w http.ResponseWriter, r *http.Request) {
+		// runapi
+		// @catalog 用户
+		// @title 嵌套的匿名函数
+		// @method get
+		// @url /admin/users
+		mux.HandleFunc("GET /admin/users", func(w http.ResponseWriter, r *http.Request) {})
+	})
+}
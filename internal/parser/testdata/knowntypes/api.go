@@ -0,0 +1,57 @@
+package app
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"example.com/app/money"
+	localuuid "example.com/app/uuid"
+)
+
+// UserID 用户ID
+type UserID int64
+
+// Timestamp 时间戳
+type Timestamp time.Duration
+
+// Tags 标签
+type Tags []string
+
+// Scores 分数
+type Scores map[string]int
+
+// Order 订单
+type Order struct {
+	ID        uuid.UUID       `json:"id"`         // ID
+	UserID    UserID          `json:"user_id"`    // 用户ID
+	Amount    decimal.Decimal `json:"amount"`     // 金额
+	Price     money.Money     `json:"price"`      // 价格
+	CreatedAt time.Time       `json:"created_at"` // 创建时间
+	Timeout   Timestamp       `json:"timeout"`    // 超时时间
+	TraceID   localuuid.UUID  `json:"trace_id"`   // 追踪ID
+	Extra     json.RawMessage `json:"extra"`      // 扩展信息
+	Data      []byte          `json:"data"`       // 数据
+	Tags      Tags            `json:"tags"`       // 标签
+	Scores    Scores          `json:"scores"`     // 分数
+}
+
+// OrderQuery 订单查询
+type OrderQuery struct {
+	UserID UserID    `form:"user_id"` // 用户ID
+	Since  time.Time `form:"since"`   // 开始时间
+	Tags   Tags      `form:"tags"`    // 标签
+	Trace  uuid.UUID `form:"trace"`   // 追踪ID
+}
+
+// GetOrder 获取订单
+// runapi
+// @catalog 订单
+// @title 获取订单
+// @method get
+// @url /order
+// @query OrderQuery
+// @response_body Order
+func GetOrder() {}
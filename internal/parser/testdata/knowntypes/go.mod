@@ -0,0 +1,13 @@
+module example.com/app
+
+go 1.21
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/shopspring/decimal v1.4.0
+)
+
+replace (
+	github.com/google/uuid => ./third_party/uuid
+	github.com/shopspring/decimal => ./third_party/decimal
+)
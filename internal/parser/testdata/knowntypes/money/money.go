@@ -0,0 +1,7 @@
+package money
+
+// Money 金额
+type Money struct {
+	Amount   int64
+	Currency string
+}
@@ -0,0 +1,7 @@
+package decimal
+
+// Decimal 十进制数
+type Decimal struct {
+	value int64
+	exp   int32
+}
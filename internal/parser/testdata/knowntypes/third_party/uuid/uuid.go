@@ -0,0 +1,4 @@
+package uuid
+
+// UUID 通用唯一标识
+type UUID [16]byte
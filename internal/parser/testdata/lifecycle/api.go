@@ -0,0 +1,39 @@
+package app
+
+// User 用户
+type User struct {
+	// Deprecated: 使用 nickname 代替
+	Name     string `json:"name"`
+	Nickname string `json:"nickname"` // 昵称
+	Old      string `json:"old"`      // 旧字段 @deprecated 下个版本删除
+	Legacy   string `json:"legacy"`   // Deprecated: 不再返回
+}
+
+// UserQuery 查询条件
+type UserQuery struct {
+	Keyword string `form:"keyword"` // 关键字
+	Q       string `form:"q"`       // 关键字 @deprecated 使用 keyword
+}
+
+// ListUsers 用户列表
+// runapi
+// @catalog 用户
+// @title 用户列表
+// @method get
+// @url /users
+// @deprecated 请使用 /v2/users
+// @since v1.2
+// @sunset 2027-01-01
+// @query UserQuery
+// @response_body User
+func ListUsers() {}
+
+// ListUsersV2 用户列表
+// runapi
+// @catalog 用户
+// @title 用户列表V2
+// @method get
+// @url /v2/users
+// @deprecated
+// @sunset 2027/01/01
+func ListUsersV2() {}
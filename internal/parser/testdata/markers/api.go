@@ -0,0 +1,57 @@
+package app
+
+// 本文件保留 //runapi 和尾随空格等未经 gofmt 格式化的写法，请勿格式化
+
+/*
+ * Create 创建
+ * runapi
+ * @catalog 标记
+ * @title 块注释
+ * @method post
+ * @url /block
+ */
+func Create() {}
+
+/*
+runapi
+@catalog 标记
+@title 无装饰块注释
+@method post
+@url /plain
+*/
+func Plain() {}
+
+//runapi
+// @catalog 标记
+// @title 无空格
+// @method get
+// @url /nospace
+func NoSpace() {}
+
+// RunAPI:   
+// @catalog 标记
+// @title 大写和尾随空格
+// @method get
+// @url /upper
+func Upper() {}
+
+// @runapi
+// @catalog 标记
+// @title at标记
+// @method get
+// @url /at
+func At() {}
+
+// apidoc
+// @catalog 标记
+// @title 自定义标记
+// @method get
+// @url /custom
+func Custom() {}
+
+// Missing 缺少标记
+// @catalog 标记
+// @title 缺少标记
+// @method get
+// @url /missing
+func Missing() {}
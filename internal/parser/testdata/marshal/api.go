@@ -0,0 +1,69 @@
+package app
+
+import (
+	"strconv"
+	"time"
+)
+
+// Version 版本号
+// @type string semver
+type Version struct {
+	Major, Minor int
+}
+
+// MarshalJSON 序列化为 "1.2"
+func (v Version) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor))), nil
+}
+
+// Payload 自定义序列化的数据
+type Payload struct {
+	Raw []byte
+}
+
+// MarshalJSON 原样输出
+func (p *Payload) MarshalJSON() ([]byte, error) {
+	return p.Raw, nil
+}
+
+// Level 等级
+type Level int
+
+// MarshalText 序列化为等级名称
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte("L" + strconv.Itoa(int(l))), nil
+}
+
+// Stamp 嵌入时间
+type Stamp struct {
+	time.Time
+}
+
+// Order 订单
+type Order struct {
+	ID      int64   `json:"id,string"`       // ID
+	Count   int     `json:"count,string"`    // 数量
+	Price   float64 `json:"price,string"`    // 价格
+	Paid    bool    `json:"paid,string"`     // 是否已支付
+	Name    string  `json:"name,string"`     // 名称
+	Version Version `json:"version"`         // 版本
+	Payload Payload `json:"payload"`         // 数据
+	Level   Level   `json:"level"`           // 等级
+	Levels  []Level `json:"levels"`          // 等级列表
+	Stamp   Stamp   `json:"stamp"`           // 时间
+	Owner   *Owner  `json:"owner,omitempty"` // 所有者
+}
+
+// Owner 所有者
+type Owner struct {
+	UID int64 `json:"uid,string"` // 用户ID
+}
+
+// GetOrder 获取订单
+// runapi
+// @catalog 订单
+// @title 获取订单
+// @method get
+// @url /order
+// @response_body Order
+func GetOrder() {}
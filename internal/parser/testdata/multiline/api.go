@@ -0,0 +1,31 @@
+package app
+
+// Login 用户登录
+// runapi
+// @title 用户登录
+// @description 用户登录的接口，
+// 支持账号密码和短信验证码两种方式
+// @method post
+// @url /login
+// @remark
+// ## 错误码
+//
+// | 错误码 | 说明 |
+// |--------|------|
+// | 1001   | 密码错误 |
+func Login() {}
+
+// Logout 退出登录
+// runapi
+// 退出后令牌失效
+// @title 退出登录
+// @method post
+// @url /logout
+// @remark 第一条备注
+// @remark 第二条备注
+// 示例：
+// ```
+// @title 不是标签
+// ```
+// @description 退出当前账号
+func Logout() {}
@@ -0,0 +1,6 @@
+package model
+
+// User 管理员
+type User struct {
+	Role string `json:"role"` // 角色
+}
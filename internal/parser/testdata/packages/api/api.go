@@ -0,0 +1,30 @@
+package api
+
+import (
+	admin "example.com/app/admin/model"
+	"example.com/app/model"
+)
+
+// Detail 详情
+type Detail struct {
+	User  model.User `json:"user"`  // 用户
+	Admin admin.User `json:"admin"` // 管理员
+}
+
+// GetDetail 获取详情
+// runapi
+// @catalog 用户
+// @title 获取详情
+// @method get
+// @url /detail
+// @response_body Detail
+func GetDetail() {}
+
+// GetAdmin 获取管理员
+// runapi
+// @catalog 用户
+// @title 获取管理员
+// @method get
+// @url /admin
+// @response_body admin.User
+func GetAdmin() {}
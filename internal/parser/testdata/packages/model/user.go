@@ -0,0 +1,6 @@
+package model
+
+// User 用户
+type User struct {
+	Name string `json:"name"` // 名称
+}
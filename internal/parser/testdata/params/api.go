@@ -0,0 +1,65 @@
+package app
+
+// Paging 分页参数
+// @group
+type Paging struct {
+	Page int `form:"page" binding:"required"` // 页码
+	Size int `form:"size"`                    // 每页数量
+}
+
+// Tenant 租户信息
+// @group Tenant header
+type Tenant struct {
+	ID string `header:"X-Tenant-Id"` // 租户ID
+}
+
+// Version 与配置文件中的参数组同名，以配置文件为准
+// @group
+type Version struct {
+	Major int `form:"major"` // 主版本号
+}
+
+// Invalid 位置无效的参数组
+// @group Invalid body
+type Invalid struct {
+	Name string `form:"name"` // 名称
+}
+
+// ListUsers 用户列表
+// runapi
+// @catalog 用户相关
+// @title 用户列表
+// @method get
+// @url /users
+// @use Paging, Tenant
+// @param size query int false 每页数量，最大100
+func ListUsers() {}
+
+// Login 登录
+// runapi
+// @catalog 用户相关/认证
+// @title 登录
+// @method post
+// @url /login
+// @exclude Auth
+func Login() {}
+
+// ListOrders 订单列表
+// runapi
+// @catalog 订单相关
+// @title 订单列表
+// @method get
+// @url /orders
+// @use Version Paging
+// @exclude X-Request-Id
+func ListOrders() {}
+
+// Ping 健康检查
+// runapi
+// @catalog 系统
+// @title 健康检查
+// @method get
+// @url /ping
+// @use Unknown
+// @exclude Nothing
+func Ping() {}
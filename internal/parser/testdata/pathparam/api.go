@@ -0,0 +1,28 @@
+package app
+
+// GetUser 获取用户
+// runapi
+// @catalog 用户
+// @title 获取用户
+// @method get
+// @url {{host}}/api/user/{id}
+// @param id path int true 用户ID
+func GetUser() {}
+
+// GetFile 获取文件
+// runapi
+// @catalog 文件
+// @title 获取文件
+// @method get
+// @router /files/:bucket/*filepath
+// @param bucket path string true 存储桶
+func GetFile() {}
+
+// DeleteUser 删除用户
+// runapi
+// @catalog 用户
+// @title 删除用户
+// @method delete
+// @url /api/user
+// @param id path int true 用户ID
+func DeleteUser() {}
@@ -0,0 +1,36 @@
+package app
+
+// Node 树节点
+type Node struct {
+	Name     string `json:"name"`     // 名称
+	Children []Node `json:"children"` // 子节点
+	Parent   *Node  `json:"parent"`   // 父节点
+}
+
+// A 相互引用
+type A struct {
+	B *B `json:"b"` // B
+}
+
+// B 相互引用
+type B struct {
+	A *A `json:"a"` // A
+}
+
+// Tree 树
+// runapi
+// @catalog 树
+// @title 获取树
+// @method get
+// @url /tree
+// @response_body Node
+func Tree() {}
+
+// Mutual 相互引用
+// runapi
+// @catalog 树
+// @title 相互引用
+// @method get
+// @url /mutual
+// @response_body A
+func Mutual() {}
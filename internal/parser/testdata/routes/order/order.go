@@ -0,0 +1,17 @@
+package order
+
+// Show 订单详情
+// runapi
+// @catalog 订单
+// @title 订单详情
+// @param id path long true 订单ID
+func Show() {}
+
+// Handler 订单处理器
+type Handler struct{}
+
+// Create 创建订单
+// runapi
+// @catalog 订单
+// @title 创建订单
+func (h *Handler) Create() {}
@@ -0,0 +1,26 @@
+// Package router 模拟 gin/chi 风格的路由注册接口
+package router
+
+// HandlerFunc 处理函数
+type HandlerFunc func()
+
+// Group 路由分组
+type Group struct{}
+
+// New 创建路由
+func New() *Group { return &Group{} }
+
+// Group 创建带前缀的分组
+func (g *Group) Group(path string) *Group { return g }
+
+// GET 注册 GET 路由
+func (g *Group) GET(path string, handlers ...HandlerFunc) {}
+
+// POST 注册 POST 路由
+func (g *Group) POST(path string, handlers ...HandlerFunc) {}
+
+// Route 注册子路由
+func (g *Group) Route(path string, fn func(r *Group)) {}
+
+// Get 注册 GET 路由
+func (g *Group) Get(path string, handler HandlerFunc) {}
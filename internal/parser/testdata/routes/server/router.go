@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+
+	"example.com/app/order"
+	"example.com/app/router"
+	"example.com/app/user"
+)
+
+const healthPath = "/health"
+
+// Setup 注册路由
+func Setup() {
+	r := router.New()
+	r.GET(healthPath, user.Health)
+
+	api := r.Group("/api/v1")
+	user.Register(api)
+	api.Route("/orders", func(r *router.Group) {
+		r.Get("/{id}", order.Show)
+	})
+
+	h := &order.Handler{}
+	api.POST("/orders", auth, h.Create)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", user.Login)
+}
+
+func auth() {}
@@ -0,0 +1,50 @@
+package user
+
+import (
+	"net/http"
+
+	"example.com/app/router"
+)
+
+// Register 注册用户路由
+func Register(g *router.Group) {
+	g.GET("/users", List)
+	g.GET("/users/:id", Get)
+	g.GET("/export", Export)
+	g.POST("/export", Export)
+}
+
+// List 用户列表
+// runapi
+// @catalog 用户
+// @title 用户列表
+func List() {}
+
+// Get 获取用户
+// runapi
+// @catalog 用户
+// @title 获取用户
+// @method post
+// @url /api/v1/users/{id}
+// @param id path long true 用户ID
+func Get() {}
+
+// Export 导出用户
+// runapi
+// @catalog 用户
+// @title 导出用户
+func Export() {}
+
+// Health 健康检查
+// runapi
+// @catalog 系统
+// @title 健康检查
+// @method get
+// @router /health
+func Health() {}
+
+// Login 登录
+// runapi
+// @catalog 用户
+// @title 登录
+func Login(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,23 @@
+package api
+
+// Address 地址
+type Address struct {
+	City string `json:"city"` // 城市
+}
+
+// User 用户
+type User struct {
+	ID      int64     `json:"id"`             // ID
+	Name    string    `json:"name,omitempty"` // 名称
+	Address Address   `json:"address"`        // 地址
+	Friends []Address `json:"friends"`        // 常用地址
+}
+
+// Get 获取用户
+// runapi
+// @catalog 用户
+// @title 获取用户
+// @method get
+// @url /user
+// @response_body User
+func Get() {}
@@ -0,0 +1,37 @@
+package app
+
+// Profile 个人信息
+// runapi
+// @catalog 认证
+// @title 默认认证
+// @method get
+// @url /profile
+func Profile() {}
+
+// Login 登录
+// runapi
+// @catalog 认证
+// @title 不需要认证
+// @method post
+// @url /login
+// @security none
+func Login() {}
+
+// Export 导出
+// runapi
+// @catalog 认证
+// @title 多个认证方式
+// @method get
+// @url /export
+// @security apikey session
+// @security token
+func Export() {}
+
+// Hook 回调
+// runapi
+// @catalog 认证
+// @title 未定义的认证方式
+// @method post
+// @url /hook
+// @security oauth2
+func Hook() {}
@@ -0,0 +1,46 @@
+package api
+
+import "example.com/app/model"
+
+// CreateUser 创建用户
+// @Summary 创建用户
+// @Description 创建新用户
+// @Tags 用户管理,后台
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID" minimum(1)
+// @Param role query string false "角色" enums(admin,user)
+// @Param X-Trace header string false "追踪ID" maxlength(32)
+// @Param body body model.CreateUserRequest true "请求体"
+// @Success 200 {object} model.User "成功"
+// @Success 201 {object} model.ErrorResponse "忽略"
+// @Failure 400 {object} model.ErrorResponse "参数错误"
+// @Failure 500 {string} string "服务错误"
+// @Failure default {object} model.ErrorResponse
+// @Header 200 {string} X-Request-Id "请求ID"
+// @Security ApiKeyAuth
+// @Router /users/{id} [post]
+func CreateUser(body model.CreateUserRequest) model.User { return model.User{} }
+
+// ListUsers 用户列表
+// @Description 查询用户列表
+// @Tags 用户管理
+// @Param query query model.UserQuery false "查询条件"
+// @Success 200 {array} model.User
+// @Deprecated
+// @Router /users [get]
+func ListUsers(query model.UserQuery) []model.User { return nil }
+
+// Ping 健康检查
+// runapi
+// @catalog 系统
+// @title 健康检查
+// @method get
+// @url /ping
+// @Router /ignored [post]
+func Ping() {}
+
+// Upload 没有 @Router 的 swag 注释不会被解析
+// @Summary 上传
+// @Tags 文件
+func Upload() {}
@@ -0,0 +1,22 @@
+package model
+
+// CreateUserRequest 创建用户请求
+type CreateUserRequest struct {
+	Name string `json:"name"` // 名称
+}
+
+// User 用户
+type User struct {
+	ID   int64  `json:"id"`   // ID
+	Name string `json:"name"` // 名称
+}
+
+// ErrorResponse 错误响应
+type ErrorResponse struct {
+	Message string `json:"message"` // 错误信息
+}
+
+// UserQuery 查询条件
+type UserQuery struct {
+	Keyword string `form:"keyword"` // 关键字
+}
@@ -0,0 +1,40 @@
+package app
+
+import "time"
+
+// Status 状态
+type Status int
+
+// Tags 标签列表
+type Tags []string
+
+// SearchRequest 搜索请求
+type SearchRequest struct {
+	Page    int           `form:"page" binding:"required,min=1,max=100"` // 页码
+	Size    int           `form:"size" binding:"gt=0,lt=50"`             // 每页数量
+	Keyword string        `form:"keyword" validate:"min=2,max=20"`       // 关键字
+	Sort    string        `form:"sort" binding:"oneof=asc desc"`         // 排序
+	Email   string        `form:"email" binding:"omitempty,email"`       // 邮箱
+	Code    string        `form:"code" binding:"alphanum,len=6"`         // 验证码
+	Status  Status        `form:"status" binding:"gte=0,lte=3"`          // 状态
+	Timeout time.Duration `form:"timeout" binding:"min=1"`               // 超时时间
+	Level   string        `form:"level" binding:"eq=high"`               // 级别
+	Type    string        `form:"type" enums:"a,b,c"`                    // 类型
+}
+
+// CreateRequest 创建请求
+type CreateRequest struct {
+	IDs    []int64  `json:"ids" binding:"required,min=1,max=10,dive,gt=0"` // ID列表
+	Tags   Tags     `json:"tags" binding:"max=5,dive,max=8"`               // 标签
+	Emails []string `json:"emails" binding:"dive,email"`                   // 邮箱列表
+}
+
+// Search 搜索
+// runapi
+// @catalog 搜索
+// @title 搜索
+// @method get
+// @url /search
+// @query SearchRequest
+// @body CreateRequest
+func Search() {}
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// typeKind 类型表达式种类
+type typeKind int
+
+const (
+	kindNamed   typeKind = iota // 命名类型，如 int、user.User、Page[T]
+	kindPointer                 // 指针 *T
+	kindSlice                   // 切片 []T
+	kindArray                   // 数组 [N]T
+	kindMap                     // map[K]V
+)
+
+// typeExpr 类型表达式，用于解析字段类型字符串
+// 命名类型的名称可以包含完整包路径，如 example/model/user.User
+type typeExpr struct {
+	Kind typeKind
+	Name string      // 命名类型名称
+	Args []*typeExpr // 泛型实参
+	Len  string      // 数组长度
+	Key  *typeExpr   // map的key类型
+	Elem *typeExpr   // 指针、切片、数组、map的元素类型
+}
+
+// parseTypeExpr 解析类型字符串
+func parseTypeExpr(s string) (*typeExpr, error) {
+	r := &typeExprReader{src: s}
+	expr, err := r.parse()
+	if err != nil {
+		return nil, err
+	}
+	r.skipSpaces()
+	if r.pos != len(r.src) {
+		return nil, fmt.Errorf("类型 %s 在位置 %d 存在多余内容", s, r.pos)
+	}
+	return expr, nil
+}
+
+// String 将类型表达式还原为类型字符串
+func (t *typeExpr) String() string {
+	switch t.Kind {
+	case kindPointer:
+		return "*" + t.Elem.String()
+	case kindSlice:
+		return "[]" + t.Elem.String()
+	case kindArray:
+		return "[" + t.Len + "]" + t.Elem.String()
+	case kindMap:
+		return "map[" + t.Key.String() + "]" + t.Elem.String()
+	default:
+		if len(t.Args) == 0 {
+			return t.Name
+		}
+		args := make([]string, len(t.Args))
+		for i, arg := range t.Args {
+			args[i] = arg.String()
+		}
+		return t.Name + "[" + strings.Join(args, ",") + "]"
+	}
+}
+
+// element 返回去除指针、切片、数组、map后的最内层类型，map取值类型
+func (t *typeExpr) element() *typeExpr {
+	for t.Kind == kindPointer || t.Kind == kindSlice || t.Kind == kindArray || t.Kind == kindMap {
+		t = t.Elem
+	}
+	return t
+}
+
+// substitute 将类型参数替换为实参，返回新的类型表达式
+func (t *typeExpr) substitute(params map[string]*typeExpr) *typeExpr {
+	if t == nil {
+		return nil
+	}
+	if t.Kind == kindNamed && len(t.Args) == 0 {
+		if arg, exists := params[t.Name]; exists {
+			return arg
+		}
+	}
+
+	result := *t
+	result.Key = t.Key.substitute(params)
+	result.Elem = t.Elem.substitute(params)
+	if len(t.Args) > 0 {
+		result.Args = make([]*typeExpr, len(t.Args))
+		for i, arg := range t.Args {
+			result.Args[i] = arg.substitute(params)
+		}
+	}
+	return &result
+}
+
+// typeExprReader 类型字符串读取器
+type typeExprReader struct {
+	src string
+	pos int
+}
+
+// parse 解析一个类型
+func (r *typeExprReader) parse() (*typeExpr, error) {
+	r.skipSpaces()
+	switch {
+	case r.consume("*"):
+		elem, err := r.parse()
+		if err != nil {
+			return nil, err
+		}
+		return &typeExpr{Kind: kindPointer, Elem: elem}, nil
+	case r.consume("[]"):
+		elem, err := r.parse()
+		if err != nil {
+			return nil, err
+		}
+		return &typeExpr{Kind: kindSlice, Elem: elem}, nil
+	case r.consume("..."):
+		// 可变参数按切片处理
+		elem, err := r.parse()
+		if err != nil {
+			return nil, err
+		}
+		return &typeExpr{Kind: kindSlice, Elem: elem}, nil
+	case r.consume("map["):
+		key, err := r.parse()
+		if err != nil {
+			return nil, err
+		}
+		if !r.consume("]") {
+			return nil, fmt.Errorf("类型 %s 的map缺少 ]", r.src)
+		}
+		elem, err := r.parse()
+		if err != nil {
+			return nil, err
+		}
+		return &typeExpr{Kind: kindMap, Key: key, Elem: elem}, nil
+	case r.peek() == '[':
+		end := strings.IndexByte(r.src[r.pos:], ']')
+		if end == -1 {
+			return nil, fmt.Errorf("类型 %s 的数组缺少 ]", r.src)
+		}
+		length := strings.TrimSpace(r.src[r.pos+1 : r.pos+end])
+		r.pos += end + 1
+		elem, err := r.parse()
+		if err != nil {
+			return nil, err
+		}
+		return &typeExpr{Kind: kindArray, Len: length, Elem: elem}, nil
+	}
+
+	name := r.readName()
+	if name == "" {
+		return nil, fmt.Errorf("类型 %s 在位置 %d 缺少类型名", r.src, r.pos)
+	}
+	expr := &typeExpr{Kind: kindNamed, Name: name}
+
+	// 泛型实参
+	if r.consume("[") {
+		for {
+			arg, err := r.parse()
+			if err != nil {
+				return nil, err
+			}
+			expr.Args = append(expr.Args, arg)
+			r.skipSpaces()
+			if r.consume(",") {
+				continue
+			}
+			if r.consume("]") {
+				break
+			}
+			return nil, fmt.Errorf("类型 %s 的泛型实参缺少 ]", r.src)
+		}
+	}
+	return expr, nil
+}
+
+// readName 读取类型名，名称中的 {} 和 () 需要成对出现，如 interface{}、func()
+func (r *typeExprReader) readName() string {
+	start := r.pos
+	depth := 0
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		if depth == 0 && (c == '[' || c == ']' || c == ',') {
+			break
+		}
+		switch c {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+		}
+		r.pos++
+	}
+	return strings.TrimSpace(r.src[start:r.pos])
+}
+
+// consume 如果当前位置以 prefix 开头则前进并返回true
+func (r *typeExprReader) consume(prefix string) bool {
+	r.skipSpaces()
+	if strings.HasPrefix(r.src[r.pos:], prefix) {
+		r.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+// peek 返回当前字符
+func (r *typeExprReader) peek() byte {
+	r.skipSpaces()
+	if r.pos >= len(r.src) {
+		return 0
+	}
+	return r.src[r.pos]
+}
+
+// skipSpaces 跳过空白字符
+func (r *typeExprReader) skipSpaces() {
+	for r.pos < len(r.src) && r.src[r.pos] == ' ' {
+		r.pos++
+	}
+}
+
+// splitTopLevel 按分隔符拆分字符串，忽略 [] 和 {} 内部的分隔符
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// splitLeadingType 拆分以类型开头的注释值，类型中 [] 和 {} 内部的空格不作为分隔
+// 如 "Result[A, B]{data=C} 参数错误" 拆分为 "Result[A, B]{data=C}" 和 "参数错误"
+func splitLeadingType(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+		case ' ', '\t':
+			if depth == 0 {
+				return s[:i], strings.TrimSpace(s[i+1:])
+			}
+		}
+	}
+	return s, ""
+}
@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// validatorFormats go-playground/validator 规则对应的格式
+var validatorFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"uuid4":    "uuid",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"datetime": "date-time",
+}
+
+// validatorPatterns go-playground/validator 规则对应的正则表达式
+var validatorPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+}
+
+// constraintKind 校验规则作用的值类型
+type constraintKind int
+
+const (
+	constraintNumber constraintKind = iota // 数值，min/max 为取值范围
+	constraintString                       // 字符串，min/max 为长度
+	constraintItems                        // 数组和map，min/max 为元素个数
+	constraintOther                        // 其他类型，忽略 min/max
+)
+
+// maxNamedTypeDepth 解析命名类型的底层类型时的最大层数，避免 type A B、type B A 这样的循环
+const maxNamedTypeDepth = 8
+
+// parseConstraints 从 binding/validate 标签中提取校验约束，enums 标签作为可选值
+// dive 之后的规则作用于数组元素，按元素类型解析
+func (p *Parser) parseConstraints(currentPackage, tag, goType string) types.Constraints {
+	var constraints types.Constraints
+	structTag := reflect.StructTag(tag)
+
+	for _, key := range []string{"binding", "validate"} {
+		rules, exists := structTag.Lookup(key)
+		if !exists {
+			continue
+		}
+
+		kind := p.constraintKindOf(currentPackage, goType)
+		for _, rule := range strings.Split(rules, ",") {
+			name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+			if name == "dive" {
+				kind = p.constraintKindOf(currentPackage, p.elementGoType(currentPackage, goType))
+				continue
+			}
+			applyValidatorRule(&constraints, kind, name, param)
+		}
+	}
+
+	if enums := structTag.Get("enums"); enums != "" {
+		constraints.Enum = nil
+		for _, enum := range strings.Split(enums, ",") {
+			constraints.Enum = append(constraints.Enum, strings.TrimSpace(enum))
+		}
+	}
+
+	return constraints
+}
+
+// applyValidatorRule 将单条校验规则写入约束
+func applyValidatorRule(c *types.Constraints, kind constraintKind, name, param string) {
+	if format, exists := validatorFormats[name]; exists {
+		c.Format = format
+		return
+	}
+	if pattern, exists := validatorPatterns[name]; exists {
+		c.Pattern = pattern
+		return
+	}
+
+	switch name {
+	case "oneof":
+		c.Enum = strings.Fields(param)
+	case "min", "gte":
+		setBound(c, kind, param, 0, true)
+	case "max", "lte":
+		setBound(c, kind, param, 0, false)
+	case "gt":
+		setBound(c, kind, param, 1, true)
+	case "lt":
+		setBound(c, kind, param, -1, false)
+	case "len":
+		setBound(c, kind, param, 0, true)
+		setBound(c, kind, param, 0, false)
+	case "eq":
+		// 字符串的 eq 比较的是值而不是长度
+		if kind == constraintString {
+			c.Enum = []string{param}
+			return
+		}
+		setBound(c, kind, param, 0, true)
+		setBound(c, kind, param, 0, false)
+	}
+}
+
+// setBound 设置上下界，offset 用于将 gt/lt 转换为长度和元素个数的闭区间
+func setBound(c *types.Constraints, kind constraintKind, param string, offset int, lower bool) {
+	switch kind {
+	case constraintNumber:
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		switch {
+		case lower && offset != 0:
+			c.ExclusiveMinimum = &value
+		case lower:
+			c.Minimum = &value
+		case offset != 0:
+			c.ExclusiveMaximum = &value
+		default:
+			c.Maximum = &value
+		}
+	case constraintString, constraintItems:
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		value += offset
+		switch {
+		case lower && kind == constraintString:
+			c.MinLength = &value
+		case lower:
+			c.MinItems = &value
+		case kind == constraintString:
+			c.MaxLength = &value
+		default:
+			c.MaxItems = &value
+		}
+	}
+}
+
+// constraintKindOf 返回Go类型对应的校验值类型，命名类型按底层类型判断，如 type Status int 和 time.Duration
+func (p *Parser) constraintKindOf(currentPackage, goType string) constraintKind {
+	for depth := 0; depth < maxNamedTypeDepth; depth++ {
+		expr, err := parseTypeExpr(goType)
+		if err != nil {
+			return constraintOther
+		}
+		for expr.Kind == kindPointer {
+			expr = expr.Elem
+		}
+
+		switch expr.Kind {
+		case kindSlice, kindArray, kindMap:
+			return constraintItems
+		}
+		if kind, ok := basicConstraintKind(expr.Name); ok {
+			return kind
+		}
+		if mapping, exists := p.knownType(expr.Name); exists {
+			kind, _ := basicConstraintKind(mapping.Type)
+			return kind
+		}
+		key, exists := p.findNamedType(currentPackage, "", expr.Name)
+		if !exists {
+			return constraintOther
+		}
+		goType, currentPackage = p.namedTypes[key].Underlying, p.namedTypes[key].Package
+	}
+	return constraintOther
+}
+
+// basicConstraintKind 返回基本类型对应的校验值类型，非基本类型返回 false
+func basicConstraintKind(name string) (constraintKind, bool) {
+	switch name {
+	case "string":
+		return constraintString, true
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "byte", "rune":
+		return constraintNumber, true
+	}
+	return constraintOther, false
+}
+
+// elementGoType 返回数组或map的元素类型，切片、数组和map命名类型按底层类型处理，其他类型原样返回
+func (p *Parser) elementGoType(currentPackage, goType string) string {
+	expr, err := parseTypeExpr(goType)
+	if err != nil {
+		return goType
+	}
+	for expr.Kind == kindPointer {
+		expr = expr.Elem
+	}
+	if expr.Kind == kindNamed {
+		if key, exists := p.findNamedType(currentPackage, "", expr.Name); exists {
+			if underlying, err := parseTypeExpr(p.namedTypes[key].Underlying); err == nil && underlying.Kind != kindNamed {
+				expr = underlying
+			}
+		}
+	}
+	if expr.Kind == kindSlice || expr.Kind == kindArray || expr.Kind == kindMap {
+		return expr.Elem.String()
+	}
+	return goType
+}
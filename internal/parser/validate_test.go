@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+func TestValidateConstraints(t *testing.T) {
+	docs, diagnostics := parseTestdata(t, "validate")
+	if len(diagnostics) > 0 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+	doc := findDoc(t, docs, "搜索")
+
+	query := make(map[string]types.RequestParam)
+	for _, param := range doc.Query {
+		query[param.Name] = param
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"page", "取值 1~100"},
+		{"size", "取值 (0,50)"},
+		{"keyword", "长度 2~20"},
+		{"sort", "可选值 asc/desc"},
+		{"email", "格式 email"},
+		{"code", "长度 6，匹配 ^[a-zA-Z0-9]+$"},
+		{"status", "取值 0~3"},
+		{"timeout", "取值 ≥1"},
+		{"level", "可选值 high"},
+		{"type", "可选值 a/b/c"},
+	}
+	for _, tt := range tests {
+		param, exists := query[tt.name]
+		if !exists {
+			t.Errorf("missing query param %s", tt.name)
+			continue
+		}
+		if got := param.Constraints.Describe(); got != tt.want {
+			t.Errorf("%s constraints = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if query["page"].Require != "true" || query["email"].Require != "false" {
+		t.Errorf("require = %s/%s", query["page"].Require, query["email"].Require)
+	}
+
+	// dive 之后的规则按元素类型解析，树形结构中数组节点只保留元素个数，其余约束作用于元素
+	bodyTests := []struct {
+		path  string
+		array string
+		items string
+		flat  string
+	}{
+		{"ids", "元素个数 1~10", "取值 >0", "取值 >0，元素个数 1~10"},
+		{"tags", "元素个数 ≤5", "长度 ≤8", "长度 ≤8，元素个数 ≤5"},
+		{"emails", "", "格式 email", "格式 email"},
+	}
+	body := make(map[string]types.RequestParam)
+	for _, param := range doc.Body {
+		body[param.Name] = param
+	}
+	for _, tt := range bodyTests {
+		node := schemaAt(t, doc.BodySchema, tt.path)
+		if got := node.Constraints.Describe(); got != tt.array {
+			t.Errorf("%s constraints = %q, want %q", tt.path, got, tt.array)
+		}
+		if got := node.Items.Constraints.Describe(); got != tt.items {
+			t.Errorf("%s items constraints = %q, want %q", tt.path, got, tt.items)
+		}
+		if got := body[tt.path].Constraints.Describe(); got != tt.flat {
+			t.Errorf("%s param constraints = %q, want %q", tt.path, got, tt.flat)
+		}
+	}
+}
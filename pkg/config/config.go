@@ -5,8 +5,44 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
+// 输出格式
+const (
+	FormatJSON    = "json"    // 自定义JSON文档（始终生成，push/genpush依赖该文件）
+	FormatOpenAPI = "openapi" // OpenAPI 3.1 文档
+)
+
+// 注释格式
+const (
+	DialectRunAPI = "runapi" // runapi 注释（默认）
+	DialectSwag   = "swag"   // 同时解析 swaggo/swag 注释，带 runapi 标记的注释仍按 runapi 格式解析
+)
+
+// DefaultMarker 默认的文档注释标记关键字
+const DefaultMarker = "runapi"
+
+// DefaultDataField 统一响应结构中默认的业务数据字段
+const DefaultDataField = "data"
+
+// 结构体解析模式
+const (
+	ResolverAST      = "ast"      // 基于AST和包名匹配解析（默认）
+	ResolverPackages = "packages" // 基于 go/packages 和类型检查解析，引用解析为完整包路径
+)
+
+// 认证方式类型
+const (
+	SecurityBearer = "bearer" // 请求头 Authorization: Bearer <token>
+	SecurityAPIKey = "apiKey" // 请求头或查询参数中的API Key
+	SecurityBasic  = "basic"  // HTTP Basic 认证
+	SecurityCookie = "cookie" // Cookie 中的会话标识
+)
+
+// SecurityNone 表示接口不需要认证，用于 @security none 取消默认的认证方式
+const SecurityNone = "none"
+
 // Config 应用配置结构
 type Config struct {
 	// 扫描配置
@@ -17,6 +53,15 @@ type Config struct {
 
 	// ShowDoc配置
 	ShowDoc ShowDocConfig `json:"showdoc"`
+
+	// 认证配置
+	Security SecurityConfig `json:"security"`
+
+	// 公共参数配置
+	Params ParamsConfig `json:"params"`
+
+	// 统一响应配置
+	Response ResponseConfig `json:"response"`
 }
 
 // ScanConfig 扫描配置
@@ -25,11 +70,48 @@ type ScanConfig struct {
 	Scan          string   `json:"scan"`           // 带文档注释的文件扫描路径（可选，默认同dir）
 	ExtraDirs     []string `json:"extra_dirs"`     // 额外的扫描目录
 	IncludeVendor bool     `json:"include_vendor"` // 是否包含vendor目录
+	Resolver      string   `json:"resolver"`       // 结构体解析模式：ast（默认）、packages
+	MaxDepth      int      `json:"max_depth"`      // 结构体最大展开深度，0表示不限制
+	Marker        string   `json:"marker"`         // 文档注释的标记关键字，默认 runapi
+	Dialect       string   `json:"dialect"`        // 注释格式：runapi（默认）、swag
+	Routes        bool     `json:"routes"`         // 是否从路由注册代码中识别接口的请求方法和路径
+	Strict        bool     `json:"strict"`         // 严格模式，存在警告时运行失败
+
+	TypeMapping map[string]TypeMapping `json:"type_mapping,omitempty"` // 自定义类型映射，key 为Go类型，如 time.Time、model.Money
+}
+
+// TypeMapping 将Go类型映射为文档类型
+type TypeMapping struct {
+	Type   string `json:"type"`             // 文档类型，如 string、long、double，也可以是Go基本类型，如 int64
+	Format string `json:"format,omitempty"` // 格式，如 date-time、uuid
 }
 
 // OutputConfig 输出配置
 type OutputConfig struct {
-	File string `json:"file"` // 输出文件路径
+	File    string        `json:"file"`    // 输出文件路径
+	Format  []string      `json:"format"`  // 输出格式列表：json、openapi
+	OpenAPI OpenAPIConfig `json:"openapi"` // OpenAPI导出配置
+}
+
+// OpenAPIConfig OpenAPI导出配置
+type OpenAPIConfig struct {
+	File    string   `json:"file"`    // 输出文件路径（可选，默认与file同目录，如 api-docs.openapi.json）
+	Title   string   `json:"title"`   // 文档标题
+	Version string   `json:"version"` // 文档版本
+	Servers []string `json:"servers"` // 服务地址列表
+}
+
+// HasFormat 检查是否启用了指定的输出格式
+func (o OutputConfig) HasFormat(format string) bool {
+	if format == FormatJSON {
+		return true
+	}
+	for _, f := range o.Format {
+		if strings.EqualFold(f, format) {
+			return true
+		}
+	}
+	return false
 }
 
 // ShowDocConfig ShowDoc配置
@@ -40,18 +122,125 @@ type ShowDocConfig struct {
 	Enabled  bool   `json:"enabled"`   // 是否启用ShowDoc推送
 }
 
+// SecurityConfig 认证配置
+type SecurityConfig struct {
+	Schemes map[string]SecurityScheme `json:"schemes,omitempty"` // 认证方式，key 为在 @security 中引用的名称
+	Default []string                  `json:"default,omitempty"` // 默认的认证方式，接口没有 @security 时使用
+}
+
+// SecurityScheme 认证方式
+type SecurityScheme struct {
+	Type         string `json:"type"`                    // 类型：bearer、apiKey、basic、cookie
+	In           string `json:"in,omitempty"`            // apiKey 的位置：header（默认）、query
+	Name         string `json:"name,omitempty"`          // apiKey 的请求头或参数名，cookie 的名称
+	BearerFormat string `json:"bearer_format,omitempty"` // bearer 令牌的格式，如 JWT
+	Description  string `json:"description,omitempty"`   // 说明
+}
+
+// validate 校验认证配置，并设置 apiKey 的默认位置
+func (s *SecurityConfig) validate() error {
+	for name, scheme := range s.Schemes {
+		if name == SecurityNone {
+			return fmt.Errorf("认证方式名称 %s 为保留名称", name)
+		}
+		switch scheme.Type {
+		case SecurityBearer, SecurityBasic:
+		case SecurityAPIKey:
+			if scheme.In == "" {
+				scheme.In = "header"
+			}
+			if scheme.In != "header" && scheme.In != "query" {
+				return fmt.Errorf("认证方式 %s 的位置 %s 无效，应为 header 或 query", name, scheme.In)
+			}
+			fallthrough
+		case SecurityCookie:
+			if scheme.Name == "" {
+				return fmt.Errorf("认证方式 %s 缺少 name", name)
+			}
+		default:
+			return fmt.Errorf("认证方式 %s 的类型 %s 无效，应为 bearer、apiKey、basic 或 cookie", name, scheme.Type)
+		}
+		s.Schemes[name] = scheme
+	}
+
+	for _, name := range s.Default {
+		if _, exists := s.Schemes[name]; !exists {
+			return fmt.Errorf("默认认证方式 %s 未定义", name)
+		}
+	}
+	return nil
+}
+
+// ParamsConfig 公共参数配置
+type ParamsConfig struct {
+	Groups  map[string][]ParamDefinition `json:"groups,omitempty"`  // 参数组，key 为在 @use 中引用的名称
+	Globals []GlobalParams               `json:"globals,omitempty"` // 按目录前缀添加到接口的参数组
+}
+
+// ParamDefinition 参数组中的参数
+type ParamDefinition struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`                 // 位置：header、query、path、formData
+	Type     string `json:"type,omitempty"`     // 类型，默认为 string
+	Required bool   `json:"required,omitempty"` // 是否必传
+	Remark   string `json:"remark,omitempty"`   // 说明
+}
+
+// GlobalParams 全局参数，目录为指定前缀的接口都会添加这些参数组
+type GlobalParams struct {
+	Catalog string   `json:"catalog,omitempty"` // 目录前缀，如 用户相关 匹配 用户相关 和 用户相关/登录，为空时匹配所有接口
+	Use     []string `json:"use"`               // 参数组名称
+}
+
+// validate 校验参数组配置，并设置参数的默认类型
+func (c *ParamsConfig) validate() error {
+	for name, params := range c.Groups {
+		for i, param := range params {
+			if param.Name == "" {
+				return fmt.Errorf("参数组 %s 的第 %d 个参数缺少 name", name, i+1)
+			}
+			switch param.In {
+			case "header", "query", "path", "formData":
+			default:
+				return fmt.Errorf("参数组 %s 的参数 %s 的位置 %s 无效，应为 header、query、path 或 formData", name, param.Name, param.In)
+			}
+			if param.Type == "" {
+				params[i].Type = "string"
+			}
+		}
+	}
+	return nil
+}
+
+// ResponseConfig 统一响应配置
+type ResponseConfig struct {
+	Envelope  string `json:"envelope,omitempty"`   // 统一响应结构体，如 response.Response，为空时不包装
+	DataField string `json:"data_field,omitempty"` // 业务数据字段的JSON名称，默认为 data
+}
+
 // LoadConfig 加载配置文件，支持多级覆盖
 func LoadConfig(currentDir, configPath string) (*Config, error) {
 	config := &Config{
 		Scan: ScanConfig{
-			Dir: ".",
+			Dir:      ".",
+			Resolver: ResolverAST,
+			Marker:   DefaultMarker,
+			Dialect:  DialectRunAPI,
 		},
 		Output: OutputConfig{
-			File: "api-docs.json",
+			File:   "api-docs.json",
+			Format: []string{FormatJSON},
+			OpenAPI: OpenAPIConfig{
+				Title:   "API文档",
+				Version: "1.0.0",
+			},
 		},
 		ShowDoc: ShowDocConfig{
 			Enabled: false,
 		},
+		Response: ResponseConfig{
+			DataField: DefaultDataField,
+		},
 	}
 
 	// 1. 加载当前运行目录的配置文件
@@ -92,6 +281,44 @@ func LoadConfig(currentDir, configPath string) (*Config, error) {
 		}
 	}
 
+	if config.Scan.Resolver != ResolverAST && config.Scan.Resolver != ResolverPackages {
+		return nil, fmt.Errorf("无效的结构体解析模式: %s", config.Scan.Resolver)
+	}
+
+	if config.Scan.Dialect != DialectRunAPI && config.Scan.Dialect != DialectSwag {
+		return nil, fmt.Errorf("无效的注释格式: %s", config.Scan.Dialect)
+	}
+
+	if config.Scan.MaxDepth < 0 {
+		return nil, fmt.Errorf("无效的结构体最大展开深度: %d", config.Scan.MaxDepth)
+	}
+
+	for _, format := range config.Output.Format {
+		if format != FormatJSON && format != FormatOpenAPI {
+			return nil, fmt.Errorf("无效的输出格式: %s，应为 json 或 openapi", format)
+		}
+	}
+
+	for goType, mapping := range config.Scan.TypeMapping {
+		if mapping.Type == "" {
+			return nil, fmt.Errorf("类型映射 %s 缺少文档类型", goType)
+		}
+	}
+
+	if err := config.Security.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Params.validate(); err != nil {
+		return nil, err
+	}
+
+	// 如果OpenAPI输出路径没指定，则写到JSON文档旁边
+	if config.Output.OpenAPI.File == "" {
+		ext := filepath.Ext(config.Output.File)
+		config.Output.OpenAPI.File = strings.TrimSuffix(config.Output.File, ext) + ".openapi.json"
+	}
+
 	return config, nil
 }
 
@@ -118,9 +345,69 @@ func loadConfigFile(filePath string, config *Config) error {
 	if tempConfig.Scan.ExtraDirs != nil {
 		config.Scan.ExtraDirs = tempConfig.Scan.ExtraDirs
 	}
+	if tempConfig.Scan.Resolver != "" {
+		config.Scan.Resolver = tempConfig.Scan.Resolver
+	}
+	if tempConfig.Scan.MaxDepth != 0 {
+		config.Scan.MaxDepth = tempConfig.Scan.MaxDepth
+	}
+	if tempConfig.Scan.Marker != "" {
+		config.Scan.Marker = tempConfig.Scan.Marker
+	}
+	if tempConfig.Scan.Dialect != "" {
+		config.Scan.Dialect = tempConfig.Scan.Dialect
+	}
+	// 类型映射按key合并，后者覆盖同名的映射
+	for goType, mapping := range tempConfig.Scan.TypeMapping {
+		if config.Scan.TypeMapping == nil {
+			config.Scan.TypeMapping = make(map[string]TypeMapping)
+		}
+		config.Scan.TypeMapping[goType] = mapping
+	}
 	if tempConfig.Output.File != "" {
 		config.Output.File = tempConfig.Output.File
 	}
+	if tempConfig.Output.Format != nil {
+		config.Output.Format = tempConfig.Output.Format
+	}
+	if tempConfig.Output.OpenAPI.File != "" {
+		config.Output.OpenAPI.File = tempConfig.Output.OpenAPI.File
+	}
+	if tempConfig.Output.OpenAPI.Title != "" {
+		config.Output.OpenAPI.Title = tempConfig.Output.OpenAPI.Title
+	}
+	if tempConfig.Output.OpenAPI.Version != "" {
+		config.Output.OpenAPI.Version = tempConfig.Output.OpenAPI.Version
+	}
+	if tempConfig.Output.OpenAPI.Servers != nil {
+		config.Output.OpenAPI.Servers = tempConfig.Output.OpenAPI.Servers
+	}
+	// 认证方式按名称合并，后者覆盖同名的认证方式
+	for name, scheme := range tempConfig.Security.Schemes {
+		if config.Security.Schemes == nil {
+			config.Security.Schemes = make(map[string]SecurityScheme)
+		}
+		config.Security.Schemes[name] = scheme
+	}
+	if tempConfig.Security.Default != nil {
+		config.Security.Default = tempConfig.Security.Default
+	}
+	// 参数组按名称合并，后者覆盖同名的参数组
+	for name, params := range tempConfig.Params.Groups {
+		if config.Params.Groups == nil {
+			config.Params.Groups = make(map[string][]ParamDefinition)
+		}
+		config.Params.Groups[name] = params
+	}
+	if tempConfig.Params.Globals != nil {
+		config.Params.Globals = tempConfig.Params.Globals
+	}
+	if tempConfig.Response.Envelope != "" {
+		config.Response.Envelope = tempConfig.Response.Envelope
+	}
+	if tempConfig.Response.DataField != "" {
+		config.Response.DataField = tempConfig.Response.DataField
+	}
 	if tempConfig.ShowDoc.URL != "" {
 		config.ShowDoc.URL = tempConfig.ShowDoc.URL
 	}
@@ -133,6 +420,8 @@ func loadConfigFile(filePath string, config *Config) error {
 	// 布尔值直接覆盖
 	config.ShowDoc.Enabled = tempConfig.ShowDoc.Enabled
 	config.Scan.IncludeVendor = tempConfig.Scan.IncludeVendor
+	config.Scan.Routes = tempConfig.Scan.Routes
+	config.Scan.Strict = tempConfig.Scan.Strict
 
 	return nil
 }
@@ -155,9 +444,21 @@ func CreateDefaultConfig(filePath string) error {
 			Scan:          "", // 将在LoadConfig中自动设置为同dir
 			ExtraDirs:     []string{},
 			IncludeVendor: false,
+			Resolver:      ResolverAST,
+			MaxDepth:      0,
+			Marker:        DefaultMarker,
+			Dialect:       DialectRunAPI,
+			Routes:        false,
+			Strict:        false,
 		},
 		Output: OutputConfig{
-			File: "api-docs.json",
+			File:   "api-docs.json",
+			Format: []string{FormatJSON},
+			OpenAPI: OpenAPIConfig{
+				Title:   "API文档",
+				Version: "1.0.0",
+				Servers: []string{},
+			},
 		},
 		ShowDoc: ShowDocConfig{
 			URL:      "https://www.showdoc.cc/server/api/open",
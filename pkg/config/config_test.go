@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{"default", `{}`, false},
+		{"json and openapi", `{"output": {"format": ["json", "openapi"]}}`, false},
+		{"unknown format", `{"output": {"format": ["json", "yaml"]}}`, true},
+		{"misspelled format", `{"output": {"format": ["openAPI3"]}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "runapi.json"), []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(dir, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecurityConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		security SecurityConfig
+		wantErr  bool
+	}{
+		{"bearer and basic", SecurityConfig{Schemes: map[string]SecurityScheme{"bearer": {Type: SecurityBearer}, "basic": {Type: SecurityBasic}}, Default: []string{"bearer"}}, false},
+		{"apiKey in query", SecurityConfig{Schemes: map[string]SecurityScheme{"token": {Type: SecurityAPIKey, In: "query", Name: "token"}}}, false},
+		{"apiKey without name", SecurityConfig{Schemes: map[string]SecurityScheme{"token": {Type: SecurityAPIKey}}}, true},
+		{"apiKey in cookie", SecurityConfig{Schemes: map[string]SecurityScheme{"token": {Type: SecurityAPIKey, In: "cookie", Name: "token"}}}, true},
+		{"cookie without name", SecurityConfig{Schemes: map[string]SecurityScheme{"session": {Type: SecurityCookie}}}, true},
+		{"unknown type", SecurityConfig{Schemes: map[string]SecurityScheme{"oauth": {Type: "oauth2"}}}, true},
+		{"reserved name", SecurityConfig{Schemes: map[string]SecurityScheme{SecurityNone: {Type: SecurityBearer}}}, true},
+		{"undefined default", SecurityConfig{Default: []string{"bearer"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.security.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	security := SecurityConfig{Schemes: map[string]SecurityScheme{"apikey": {Type: SecurityAPIKey, Name: "X-API-Key"}}}
+	if err := security.validate(); err != nil || security.Schemes["apikey"].In != "header" {
+		t.Errorf("apiKey in = %q, error = %v, want header", security.Schemes["apikey"].In, err)
+	}
+}
+
+func TestParamsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ParamsConfig
+		wantErr bool
+	}{
+		{"header and query", ParamsConfig{Groups: map[string][]ParamDefinition{"Auth": {{Name: "token", In: "header"}, {Name: "page", In: "query", Type: "int"}}}}, false},
+		{"missing name", ParamsConfig{Groups: map[string][]ParamDefinition{"Auth": {{In: "header"}}}}, true},
+		{"missing in", ParamsConfig{Groups: map[string][]ParamDefinition{"Auth": {{Name: "token"}}}}, true},
+		{"invalid in", ParamsConfig{Groups: map[string][]ParamDefinition{"Auth": {{Name: "token", In: "cookie"}}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	params := ParamsConfig{Groups: map[string][]ParamDefinition{"Auth": {{Name: "token", In: "header"}}}}
+	if err := params.validate(); err != nil || params.Groups["Auth"][0].Type != "string" {
+		t.Errorf("param type = %q, error = %v, want string", params.Groups["Auth"][0].Type, err)
+	}
+}
+
+func TestLoadConfigParamsOverride(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "custom.json")
+	files := map[string]string{
+		filepath.Join(dir, "runapi.json"): `{"params": {"groups": {"Auth": [{"name": "token", "in": "header"}], "Trace": [{"name": "X-Request-Id", "in": "header"}]}, "globals": [{"use": ["Trace"]}]}}`,
+		configPath:                        `{"params": {"groups": {"Auth": [{"name": "Authorization", "in": "header"}]}, "globals": [{"catalog": "用户相关", "use": ["Auth"]}]}}`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := LoadConfig(dir, configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	// 参数组按名称合并，globals 整体覆盖
+	if auth := cfg.Params.Groups["Auth"]; len(auth) != 1 || auth[0].Name != "Authorization" {
+		t.Errorf("Auth = %+v", auth)
+	}
+	if _, exists := cfg.Params.Groups["Trace"]; !exists {
+		t.Errorf("groups = %+v, want Trace", cfg.Params.Groups)
+	}
+	if len(cfg.Params.Globals) != 1 || cfg.Params.Globals[0].Catalog != "用户相关" {
+		t.Errorf("globals = %+v", cfg.Params.Globals)
+	}
+}
@@ -0,0 +1,192 @@
+package diagnostic
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Severity 诊断级别
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // 错误，文档无法生成
+	SeverityWarning Severity = "warning" // 警告，严格模式下视为错误
+)
+
+// 诊断代码
+const (
+	CodeParseFailed     = "parse-failed"     // 注释解析失败
+	CodeLoadPackage     = "load-package"     // 加载包出错
+	CodeMissingMarker   = "missing-marker"   // 注释缺少文档标记
+	CodeUnknownTag      = "unknown-tag"      // 未知的注释标签
+	CodeEmptyTag        = "empty-tag"        // 注释标签缺少值
+	CodeInvalidParam    = "invalid-param"    // 参数格式错误或位置无效
+	CodeInvalidMethod   = "invalid-method"   // 无效的请求方法
+	CodeInvalidResponse = "invalid-response" // 响应格式错误
+	CodeInvalidFailure  = "invalid-failure"  // 失败响应格式错误
+	CodeStructNotFound  = "struct-not-found" // 未找到引用的结构体
+	CodeEmbedding       = "embedding"        // 嵌入字段无法展开
+	CodePathParam       = "path-param"       // 路径参数与路由占位符不一致
+	CodeRouteMismatch   = "route-mismatch"   // 声明的路由与注册的路由不一致
+	CodeRouteAmbiguous  = "route-ambiguous"  // 路由的处理函数无法确定或注册了多个路由
+	CodeRequiredField   = "required-field"   // 缺少必填字段
+	CodeInvalidSunset   = "invalid-sunset"   // 计划下线日期格式错误
+	CodeUnknownSecurity = "unknown-security" // 未定义的认证方式
+	CodeParamGroup      = "param-group"      // 参数组未定义或重复定义，@exclude 没有匹配的参数
+	CodeDuplicateRoute  = "duplicate-route"  // 多个接口使用相同的请求方法和路径
+)
+
+// Diagnostic 诊断信息
+type Diagnostic struct {
+	Severity   Severity `json:"severity"`
+	File       string   `json:"file,omitempty"`
+	Line       int      `json:"line,omitempty"`
+	Column     int      `json:"column,omitempty"`
+	Code       string   `json:"code"`
+	Message    string   `json:"message"`
+	Suggestion string   `json:"suggestion,omitempty"` // 修复建议
+}
+
+// Position 返回 file:line:col 形式的位置，没有位置信息时返回空
+func (d Diagnostic) Position() string {
+	switch {
+	case d.File == "":
+		return ""
+	case d.Line == 0:
+		return d.File
+	case d.Column == 0:
+		return fmt.Sprintf("%s:%d", d.File, d.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+}
+
+// String 返回诊断信息的文本形式，如 api/user.go:12:4: 警告: 未找到结构体 User [struct-not-found]
+func (d Diagnostic) String() string {
+	label := "警告"
+	if d.Severity == SeverityError {
+		label = "错误"
+	}
+
+	var builder strings.Builder
+	if position := d.Position(); position != "" {
+		builder.WriteString(position + ": ")
+	}
+	fmt.Fprintf(&builder, "%s: %s [%s]", label, d.Message, d.Code)
+	if d.Suggestion != "" {
+		builder.WriteString("\n  修复建议: " + d.Suggestion)
+	}
+	return builder.String()
+}
+
+// Collector 诊断信息收集器，位置信息来自解析源码使用的 token.FileSet
+type Collector struct {
+	fset        *token.FileSet
+	diagnostics []Diagnostic
+	seen        map[string]bool
+}
+
+// NewCollector 创建诊断信息收集器
+func NewCollector(fset *token.FileSet) *Collector {
+	return &Collector{
+		fset: fset,
+		seen: make(map[string]bool),
+	}
+}
+
+// Reset 清空已收集的诊断信息
+func (c *Collector) Reset() {
+	c.diagnostics = nil
+	c.seen = make(map[string]bool)
+}
+
+// Add 添加诊断信息，同一位置的相同信息只保留一条
+func (c *Collector) Add(d Diagnostic) {
+	key := d.Position() + "|" + d.Code + "|" + d.Message
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	c.diagnostics = append(c.diagnostics, d)
+}
+
+// Report 添加指定位置的诊断信息，pos 无效时不带位置信息
+func (c *Collector) Report(severity Severity, pos token.Pos, code, suggestion, format string, args ...interface{}) {
+	d := Diagnostic{
+		Severity:   severity,
+		Code:       code,
+		Message:    fmt.Sprintf(format, args...),
+		Suggestion: suggestion,
+	}
+	if pos.IsValid() {
+		position := c.fset.Position(pos)
+		d.File, d.Line, d.Column = position.Filename, position.Line, position.Column
+	}
+	c.Add(d)
+}
+
+// ReportAt 添加指定文件和行号的诊断信息，用于已解析出位置的对象，如接口文档
+func (c *Collector) ReportAt(severity Severity, file string, line int, code, suggestion, format string, args ...interface{}) {
+	c.Add(Diagnostic{
+		Severity:   severity,
+		File:       file,
+		Line:       line,
+		Code:       code,
+		Message:    fmt.Sprintf(format, args...),
+		Suggestion: suggestion,
+	})
+}
+
+// Warnf 添加警告
+func (c *Collector) Warnf(pos token.Pos, code, format string, args ...interface{}) {
+	c.Report(SeverityWarning, pos, code, "", format, args...)
+}
+
+// Errorf 添加错误
+func (c *Collector) Errorf(pos token.Pos, code, format string, args ...interface{}) {
+	c.Report(SeverityError, pos, code, "", format, args...)
+}
+
+// Diagnostics 返回按文件和位置排序的诊断信息
+func (c *Collector) Diagnostics() []Diagnostic {
+	diagnostics := append([]Diagnostic(nil), c.diagnostics...)
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diagnostics
+}
+
+// Count 返回指定级别的诊断信息数量
+func Count(diagnostics []Diagnostic, severity Severity) int {
+	count := 0
+	for _, d := range diagnostics {
+		if d.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// RelativeTo 将诊断信息中的文件路径转换为相对于 dir 的路径，无法转换时保持不变
+func RelativeTo(diagnostics []Diagnostic, dir string) []Diagnostic {
+	result := make([]Diagnostic, len(diagnostics))
+	for i, d := range diagnostics {
+		if d.File != "" && filepath.IsAbs(d.File) {
+			if rel, err := filepath.Rel(dir, d.File); err == nil && !strings.HasPrefix(rel, "..") {
+				d.File = filepath.ToSlash(rel)
+			}
+		}
+		result[i] = d
+	}
+	return result
+}
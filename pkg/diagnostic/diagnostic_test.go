@@ -0,0 +1,133 @@
+package diagnostic
+
+import (
+	"bytes"
+	"go/token"
+	"testing"
+)
+
+func TestDiagnosticString(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Diagnostic
+		want string
+	}{
+		{
+			name: "warning with position",
+			d:    Diagnostic{Severity: SeverityWarning, File: "api/user.go", Line: 12, Column: 1, Code: CodeUnknownTag, Message: "未知的注释标签 @titel，已忽略"},
+			want: "api/user.go:12:1: 警告: 未知的注释标签 @titel，已忽略 [unknown-tag]",
+		},
+		{
+			name: "error with suggestion",
+			d:    Diagnostic{Severity: SeverityError, File: "api/user.go", Line: 20, Code: CodeRequiredField, Message: "函数 GetUser 的title字段是必填的", Suggestion: "在函数注释中添加 @title 接口标题"},
+			want: "api/user.go:20: 错误: 函数 GetUser 的title字段是必填的 [required-field]\n  修复建议: 在函数注释中添加 @title 接口标题",
+		},
+		{
+			name: "without position",
+			d:    Diagnostic{Severity: SeverityWarning, Code: CodeLoadPackage, Message: "加载包出错"},
+			want: "警告: 加载包出错 [load-package]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollector(t *testing.T) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("b.go", -1, 100)
+	file.SetLines([]int{0, 10, 20})
+
+	c := NewCollector(fset)
+	c.Warnf(file.Pos(25), CodeUnknownTag, "未知的注释标签 %s", "@a")
+	c.Warnf(file.Pos(25), CodeUnknownTag, "未知的注释标签 %s", "@a")
+	c.Errorf(file.Pos(3), CodeRequiredField, "缺少标题")
+	c.Add(Diagnostic{Severity: SeverityWarning, File: "a.go", Line: 1, Code: CodeParamGroup, Message: "未定义的参数组"})
+	c.ReportAt(SeverityWarning, "a.go", 2, CodePathParam, "", "接口 %s 缺少路径参数", "获取用户")
+
+	diagnostics := c.Diagnostics()
+	want := []string{"a.go:1", "a.go:2", "b.go:1:4", "b.go:3:6"}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+	for i, d := range diagnostics {
+		if d.Position() != want[i] {
+			t.Errorf("diagnostics[%d] = %s, want %s", i, d.Position(), want[i])
+		}
+	}
+	if Count(diagnostics, SeverityError) != 1 || Count(diagnostics, SeverityWarning) != 3 {
+		t.Errorf("count = %d errors, %d warnings", Count(diagnostics, SeverityError), Count(diagnostics, SeverityWarning))
+	}
+
+	c.Reset()
+	if len(c.Diagnostics()) != 0 {
+		t.Errorf("diagnostics after Reset() = %v", c.Diagnostics())
+	}
+}
+
+func TestRelativeTo(t *testing.T) {
+	diagnostics := RelativeTo([]Diagnostic{{File: "/app/api/user.go"}, {File: "/other/user.go"}, {}}, "/app")
+	want := []string{"api/user.go", "/other/user.go", ""}
+	for i, d := range diagnostics {
+		if d.File != want[i] {
+			t.Errorf("diagnostics[%d].File = %q, want %q", i, d.File, want[i])
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	diagnostics := []Diagnostic{
+		{Severity: SeverityWarning, File: "api/user.go", Line: 12, Column: 1, Code: CodeUnknownTag, Message: "未知的注释标签 @titel"},
+		{Severity: SeverityError, File: "api/a,b.go", Line: 20, Code: CodeRequiredField, Message: "缺少标题 100%", Suggestion: "添加 @title"},
+	}
+	tests := []struct {
+		format      string
+		diagnostics []Diagnostic
+		want        string
+		wantErr     bool
+	}{
+		{
+			format:      FormatText,
+			diagnostics: diagnostics[:1],
+			want:        "api/user.go:12:1: 警告: 未知的注释标签 @titel [unknown-tag]\n",
+		},
+		{
+			format:      FormatGitHub,
+			diagnostics: diagnostics,
+			want: "::warning file=api/user.go,line=12,col=1,title=runapi unknown-tag::未知的注释标签 @titel\n" +
+				"::error file=api/a%2Cb.go,line=20,title=runapi required-field::缺少标题 100%25%0A修复建议: 添加 @title\n",
+		},
+		{
+			format: FormatJSON,
+			want:   "[]\n",
+		},
+		{
+			format:      FormatJSON,
+			diagnostics: diagnostics[:1],
+			want:        "[\n\t{\n\t\t\"severity\": \"warning\",\n\t\t\"file\": \"api/user.go\",\n\t\t\"line\": 12,\n\t\t\"column\": 1,\n\t\t\"code\": \"unknown-tag\",\n\t\t\"message\": \"未知的注释标签 @titel\"\n\t}\n]\n",
+		},
+		{
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Write(&buf, tt.diagnostics, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Write() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Write() = %q, want %q", got, tt.want)
+			}
+			if ValidFormat(tt.format) == tt.wantErr {
+				t.Errorf("ValidFormat(%q) = %t", tt.format, !tt.wantErr)
+			}
+		})
+	}
+}
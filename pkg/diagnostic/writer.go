@@ -0,0 +1,92 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 诊断信息输出格式
+const (
+	FormatText   = "text"   // 文本，每条一行
+	FormatJSON   = "json"   // JSON数组
+	FormatGitHub = "github" // GitHub Actions 注解，如 ::warning file=a.go,line=1::message
+)
+
+// ValidFormat 检查输出格式是否有效
+func ValidFormat(format string) bool {
+	return format == FormatText || format == FormatJSON || format == FormatGitHub
+}
+
+// Write 按指定格式输出诊断信息，JSON格式在没有诊断信息时输出空数组
+func Write(w io.Writer, diagnostics []Diagnostic, format string) error {
+	switch format {
+	case FormatJSON:
+		if diagnostics == nil {
+			diagnostics = []Diagnostic{}
+		}
+		data, err := json.MarshalIndent(diagnostics, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case FormatGitHub:
+		for _, d := range diagnostics {
+			if _, err := fmt.Fprintln(w, githubAnnotation(d)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatText:
+		for _, d := range diagnostics {
+			if _, err := fmt.Fprintln(w, d.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("无效的诊断信息输出格式: %s", format)
+	}
+}
+
+// githubAnnotation 返回 GitHub Actions 的工作流命令
+func githubAnnotation(d Diagnostic) string {
+	command := "warning"
+	if d.Severity == SeverityError {
+		command = "error"
+	}
+
+	var properties []string
+	if d.File != "" {
+		properties = append(properties, "file="+escapeProperty(d.File))
+	}
+	if d.Line > 0 {
+		properties = append(properties, fmt.Sprintf("line=%d", d.Line))
+	}
+	if d.Column > 0 {
+		properties = append(properties, fmt.Sprintf("col=%d", d.Column))
+	}
+	properties = append(properties, "title="+escapeProperty("runapi "+d.Code))
+
+	message := d.Message
+	if d.Suggestion != "" {
+		message += "\n修复建议: " + d.Suggestion
+	}
+	return fmt.Sprintf("::%s %s::%s", command, strings.Join(properties, ","), escapeData(message))
+}
+
+// escapeData 转义工作流命令的消息内容
+func escapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeProperty 转义工作流命令的属性值
+func escapeProperty(s string) string {
+	s = escapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"bytes"
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
@@ -9,28 +10,35 @@ import (
 
 	"github.com/cheivin/go-runapi/internal/parser"
 	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/openapi"
 	"github.com/cheivin/go-runapi/pkg/types"
 )
 
 // Generator 文档生成器
 type Generator struct {
-	parser *parser.Parser
-	config *config.Config
+	parser             *parser.Parser
+	config             *config.Config
+	openAPIDiagnostics []diagnostic.Diagnostic // 最近一次生成OpenAPI文档收集的诊断信息
 }
 
 // NewGenerator 创建新的文档生成器
 func NewGenerator(cfg *config.Config) *Generator {
-	// 构建所有扫描目录：根目录 + 额外目录
-	allDirs := append([]string{cfg.Scan.Dir}, cfg.Scan.ExtraDirs...)
-
 	return &Generator{
-		parser: parser.NewParser(cfg.Scan.Scan, allDirs, cfg.Scan.IncludeVendor),
+		parser: parser.NewParser(cfg),
 		config: cfg,
 	}
 }
 
+// Diagnostics 返回最近一次解析和生成OpenAPI文档收集的诊断信息
+func (g *Generator) Diagnostics() []diagnostic.Diagnostic {
+	return append(g.parser.Diagnostics(), g.openAPIDiagnostics...)
+}
+
 // GenerateDocuments 生成文档
 func (g *Generator) GenerateDocuments() (bool, error) {
+	g.openAPIDiagnostics = nil
+
 	// 解析API文档
 	apiDocs, err := g.parser.ParseDir()
 	if err != nil {
@@ -48,29 +56,67 @@ func (g *Generator) GenerateDocuments() (bool, error) {
 		return false, fmt.Errorf("生成JSON文档失败: %v", err)
 	}
 
+	// 生成OpenAPI内容，严格模式下存在诊断问题时不写入任何文档
+	var openAPIContent string
+	if g.config.Output.HasFormat(config.FormatOpenAPI) {
+		builder := openapi.NewBuilder(g.config.Output.OpenAPI)
+		openAPIContent, err = builder.GenerateJSON(apiDocs)
+		if err != nil {
+			return false, fmt.Errorf("生成OpenAPI文档失败: %v", err)
+		}
+		g.openAPIDiagnostics = builder.Diagnostics()
+		if g.config.Scan.Strict && len(g.openAPIDiagnostics) > 0 {
+			return false, fmt.Errorf("严格模式下生成OpenAPI文档发现 %d 个诊断问题", len(g.openAPIDiagnostics))
+		}
+	}
+
+	changed, err := g.writeFile(g.config.Output.File, jsonContent)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		fmt.Printf("文档已生成到: %s (%d个API)\n", g.config.Output.File, len(apiDocs))
+	}
+
+	// 写入OpenAPI文档
+	if g.config.Output.HasFormat(config.FormatOpenAPI) {
+		openAPIChanged, err := g.writeFile(g.config.Output.OpenAPI.File, openAPIContent)
+		if err != nil {
+			return false, err
+		}
+		if openAPIChanged {
+			fmt.Printf("OpenAPI文档已生成到: %s\n", g.config.Output.OpenAPI.File)
+		}
+		changed = changed || openAPIChanged
+	}
+
+	return changed, nil
+}
+
+// writeFile 写入文档文件，内容无变化时跳过
+func (g *Generator) writeFile(filePath, content string) (bool, error) {
 	// 检查文件是否有变化
-	changed, err := g.hasFileChanged(g.config.Output.File, jsonContent)
+	changed, err := g.hasFileChanged(filePath, content)
 	if err != nil {
 		return false, fmt.Errorf("检查文件变化失败: %v", err)
 	}
 
 	if !changed {
-		fmt.Printf("文档文件 %s 无变化，跳过生成\n", g.config.Output.File)
+		fmt.Printf("文档文件 %s 无变化，跳过生成\n", filePath)
 		return false, nil
 	}
 
 	// 确保输出目录存在
-	outputDir := filepath.Dir(g.config.Output.File)
+	outputDir := filepath.Dir(filePath)
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return false, fmt.Errorf("创建输出目录失败: %v", err)
 	}
 
 	// 写入文件
-	if err := os.WriteFile(g.config.Output.File, []byte(jsonContent), 0644); err != nil {
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return false, fmt.Errorf("写入文档文件失败: %v", err)
 	}
 
-	fmt.Printf("文档已生成到: %s (%d个API)\n", g.config.Output.File, len(apiDocs))
 	return true, nil
 }
 
@@ -209,27 +255,72 @@ func (g *Generator) docsEqual(doc1, doc2 types.APIDoc) bool {
 		doc1.Method != doc2.Method ||
 		g.getRouter(doc1) != g.getRouter(doc2) ||
 		doc1.Catalog != doc2.Catalog ||
-		doc1.Remark != doc2.Remark {
+		doc1.Remark != doc2.Remark ||
+		doc1.LifecycleNotice() != doc2.LifecycleNotice() ||
+		!g.securityEqual(doc1.Security, doc2.Security) {
 		return false
 	}
 
 	// 比较参数数量
-	if len(doc1.Header) != len(doc2.Header) ||
+	if len(doc1.Path) != len(doc2.Path) ||
+		len(doc1.Header) != len(doc2.Header) ||
 		len(doc1.Query) != len(doc2.Query) ||
 		len(doc1.FormData) != len(doc2.FormData) ||
 		len(doc1.Body) != len(doc2.Body) ||
 		len(doc1.ResponseHeader) != len(doc2.ResponseHeader) ||
-		len(doc1.ResponseBody) != len(doc2.ResponseBody) {
+		len(doc1.ResponseBody) != len(doc2.ResponseBody) ||
+		len(doc1.Failures) != len(doc2.Failures) {
+		return false
+	}
+
+	// 树形结构包含示例值和可选值，仅修改 example 标签或枚举时扁平参数不变
+	if !jsonEqual(doc1.BodySchema, doc2.BodySchema) ||
+		!jsonEqual(doc1.ResponseSchema, doc2.ResponseSchema) {
 		return false
 	}
 
 	// 比较参数内容（简化比较，实际可能需要更详细的比较）
-	return g.paramsEqual(doc1.Header, doc2.Header) &&
+	return g.paramsEqual(doc1.Path, doc2.Path) &&
+		g.paramsEqual(doc1.Header, doc2.Header) &&
 		g.paramsEqual(doc1.Query, doc2.Query) &&
 		g.paramsEqual(doc1.FormData, doc2.FormData) &&
 		g.paramsEqual(doc1.Body, doc2.Body) &&
 		g.responseParamsEqual(doc1.ResponseHeader, doc2.ResponseHeader) &&
-		g.responseParamsEqual(doc1.ResponseBody, doc2.ResponseBody)
+		g.responseParamsEqual(doc1.ResponseBody, doc2.ResponseBody) &&
+		g.failuresEqual(doc1.Failures, doc2.Failures)
+}
+
+// securityEqual 比较认证方式
+func (g *Generator) securityEqual(schemes1, schemes2 []types.SecurityScheme) bool {
+	if len(schemes1) != len(schemes2) {
+		return false
+	}
+
+	for i := range schemes1 {
+		if schemes1[i] != schemes2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// failuresEqual 比较失败响应
+func (g *Generator) failuresEqual(failures1, failures2 []types.Failure) bool {
+	if len(failures1) != len(failures2) {
+		return false
+	}
+
+	for i := range failures1 {
+		if failures1[i].Status != failures2[i].Status ||
+			failures1[i].Description != failures2[i].Description ||
+			!g.responseParamsEqual(failures1[i].Body, failures2[i].Body) ||
+			!jsonEqual(failures1[i].Schema, failures2[i].Schema) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // paramsEqual 比较请求参数
@@ -238,11 +329,9 @@ func (g *Generator) paramsEqual(params1, params2 []types.RequestParam) bool {
 		return false
 	}
 
+	// 按序列化结果比较，包括校验约束和废弃状态
 	for i := range params1 {
-		if params1[i].Name != params2[i].Name ||
-			params1[i].Type != params2[i].Type ||
-			params1[i].Require != params2[i].Require ||
-			params1[i].Remark != params2[i].Remark {
+		if !jsonEqual(params1[i], params2[i]) {
 			return false
 		}
 	}
@@ -256,10 +345,9 @@ func (g *Generator) responseParamsEqual(params1, params2 []types.ResponseParam)
 		return false
 	}
 
+	// 按序列化结果比较，包括校验约束和废弃状态
 	for i := range params1 {
-		if params1[i].Name != params2[i].Name ||
-			params1[i].Type != params2[i].Type ||
-			params1[i].Remark != params2[i].Remark {
+		if !jsonEqual(params1[i], params2[i]) {
 			return false
 		}
 	}
@@ -267,6 +355,13 @@ func (g *Generator) responseParamsEqual(params1, params2 []types.ResponseParam)
 	return true
 }
 
+// jsonEqual 比较两个值序列化后的JSON，已有文档从JSON文件加载，按序列化结果比较可以忽略空切片和nil的差异
+func jsonEqual(a, b interface{}) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
+
 // DocumentDiff 文档差异
 type DocumentDiff struct {
 	Added   []types.APIDoc   `json:"added"`
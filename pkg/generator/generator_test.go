@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// testDoc 返回用于比较的接口文档，modify 用于修改其中的字段
+func testDoc(modify func(doc *types.APIDoc)) types.APIDoc {
+	doc := types.APIDoc{
+		Title:   "创建订单",
+		Catalog: "订单",
+		Method:  "post",
+		URL:     "/orders",
+		Body:    []types.RequestParam{{Name: "id", Type: "long", Require: "true"}},
+		BodySchema: &types.Schema{
+			Type:     "object",
+			Children: []*types.Schema{{Name: "id", Type: "long", Required: true}},
+		},
+		ResponseBody: []types.ResponseParam{{Name: "status", Type: "string", Required: true}},
+		ResponseSchema: &types.Schema{
+			Type:     "object",
+			Children: []*types.Schema{{Name: "status", Type: "string", Required: true}},
+		},
+		Failures: []types.Failure{{
+			Status: 400,
+			Schema: &types.Schema{Type: "object", Children: []*types.Schema{{Name: "message", Type: "string"}}},
+		}},
+	}
+	if modify != nil {
+		modify(&doc)
+	}
+	return doc
+}
+
+func TestDocsEqualSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(doc *types.APIDoc)
+		equal  bool
+	}{
+		{"unchanged", nil, true},
+		{"body example", func(doc *types.APIDoc) {
+			doc.BodySchema.Children[0].Example = "1001"
+		}, false},
+		{"response enum", func(doc *types.APIDoc) {
+			doc.ResponseSchema.Children[0].Enum = []string{"paid", "closed"}
+		}, false},
+		{"body constraints", func(doc *types.APIDoc) {
+			doc.Body[0].Constraints.Format = "uuid"
+		}, false},
+		{"schema constraints", func(doc *types.APIDoc) {
+			doc.BodySchema.Children[0].Pattern = "^[0-9]+$"
+		}, false},
+		{"failure schema", func(doc *types.APIDoc) {
+			doc.Failures[0].Schema.Children[0].Example = "参数错误"
+		}, false},
+	}
+
+	g := &Generator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.docsEqual(testDoc(nil), testDoc(tt.modify)); got != tt.equal {
+				t.Errorf("docsEqual() = %t, want %t", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestGenerateDocumentsDuplicateRoutes(t *testing.T) {
+	source := `package app
+
+// ListUsers 用户列表
+// runapi
+// @catalog 用户
+// @title 用户列表
+// @method get
+// @url /users
+func ListUsers() {}
+
+// SearchUsers 搜索用户
+// runapi
+// @catalog 用户
+// @title 搜索用户
+// @method get
+// @url /users
+func SearchUsers() {}
+`
+	for _, strict := range []bool{false, true} {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "api.go"), []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := config.LoadConfig(dir, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.Scan.Strict = strict
+		cfg.Output.File = filepath.Join(dir, "api-docs.json")
+		cfg.Output.Format = []string{config.FormatJSON, config.FormatOpenAPI}
+		cfg.Output.OpenAPI.File = filepath.Join(dir, "api-docs.openapi.json")
+
+		g := NewGenerator(cfg)
+		_, err = g.GenerateDocuments()
+		if !hasCode(g.Diagnostics(), diagnostic.CodeDuplicateRoute) {
+			t.Errorf("strict=%t diagnostics = %v, want %s", strict, g.Diagnostics(), diagnostic.CodeDuplicateRoute)
+		}
+		// 严格模式下不写入任何文档
+		_, statErr := os.Stat(cfg.Output.OpenAPI.File)
+		if (err != nil) != strict || (statErr == nil) == strict {
+			t.Errorf("strict=%t GenerateDocuments() error = %v, openapi file error = %v", strict, err, statErr)
+		}
+	}
+}
+
+// hasCode 检查是否有指定代码的诊断信息
+func hasCode(diagnostics []diagnostic.Diagnostic, code string) bool {
+	for _, d := range diagnostics {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
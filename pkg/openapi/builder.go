@@ -0,0 +1,572 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+// Builder OpenAPI文档构建器
+type Builder struct {
+	config   config.OpenAPIConfig
+	schemas  map[string]*Schema
+	variants map[string][]string // map[结构体key]组件名，必传字段不同的用法生成不同的组件
+	refs     map[string]string   // map[组件名]结构体key
+	partial  map[string]bool     // 组件定义尚未由完整展开的节点生成
+	diag     *diagnostic.Collector
+}
+
+// NewBuilder 创建新的OpenAPI文档构建器
+func NewBuilder(cfg config.OpenAPIConfig) *Builder {
+	return &Builder{
+		config:   cfg,
+		schemas:  make(map[string]*Schema),
+		variants: make(map[string][]string),
+		refs:     make(map[string]string),
+		partial:  make(map[string]bool),
+		diag:     diagnostic.NewCollector(nil),
+	}
+}
+
+// Diagnostics 返回最近一次构建收集的诊断信息
+func (b *Builder) Diagnostics() []diagnostic.Diagnostic {
+	return b.diag.Diagnostics()
+}
+
+// GenerateJSON 生成OpenAPI JSON文档
+func (b *Builder) GenerateJSON(apiDocs []types.APIDoc) (string, error) {
+	jsonData, err := json.MarshalIndent(b.Build(apiDocs), "", "\t")
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// Build 将API文档列表转换为OpenAPI文档
+func (b *Builder) Build(apiDocs []types.APIDoc) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:   b.config.Title,
+			Version: b.config.Version,
+		},
+		Paths: make(map[string]PathItem),
+	}
+
+	for _, server := range b.config.Servers {
+		doc.Servers = append(doc.Servers, Server{URL: server})
+	}
+
+	b.diag.Reset()
+	operationIDs := buildOperationIDs(apiDocs)
+	seenTags := make(map[string]bool)
+	owners := make(map[string]types.APIDoc) // map[请求方法 路径]第一个使用的接口
+	for i, apiDoc := range apiDocs {
+		router := apiDoc.URL
+		if router == "" {
+			router = apiDoc.Router
+		}
+		path := normalizePath(router)
+		method := strings.ToLower(apiDoc.Method)
+
+		// 相同的请求方法和路径只保留第一个接口
+		key := method + " " + path
+		if owner, exists := owners[key]; exists {
+			b.diag.ReportAt(diagnostic.SeverityWarning, apiDoc.FilePath, apiDoc.Line, diagnostic.CodeDuplicateRoute, "",
+				"接口 %s 的路由 %s %s 与接口 %s 重复，OpenAPI文档中已忽略", apiDoc.Title, strings.ToUpper(method), path, owner.Title)
+			continue
+		}
+		owners[key] = apiDoc
+
+		if _, exists := doc.Paths[path]; !exists {
+			doc.Paths[path] = make(PathItem)
+		}
+		doc.Paths[path][method] = b.buildOperation(apiDoc, operationIDs[i])
+
+		if apiDoc.Catalog != "" && !seenTags[apiDoc.Catalog] {
+			seenTags[apiDoc.Catalog] = true
+			doc.Tags = append(doc.Tags, Tag{Name: apiDoc.Catalog})
+		}
+	}
+
+	securitySchemes := buildSecuritySchemes(apiDocs)
+	if len(b.schemas) > 0 || len(securitySchemes) > 0 {
+		doc.Components = &Components{Schemas: b.schemas, SecuritySchemes: securitySchemes}
+	}
+
+	return doc
+}
+
+// buildOperationIDs 生成各接口的 operationId，默认为函数名
+// 不同包中的同名函数使用 包目录名.函数名，仍然重复时追加序号
+func buildOperationIDs(apiDocs []types.APIDoc) []string {
+	counts := make(map[string]int)
+	for _, apiDoc := range apiDocs {
+		counts[apiDoc.FunctionName]++
+	}
+
+	ids := make([]string, len(apiDocs))
+	used := make(map[string]bool)
+	for i, apiDoc := range apiDocs {
+		if apiDoc.FunctionName == "" {
+			continue
+		}
+		id := apiDoc.FunctionName
+		if counts[id] > 1 && apiDoc.FilePath != "" {
+			id = filepath.Base(filepath.Dir(apiDoc.FilePath)) + "." + id
+		}
+		base := id
+		for n := 2; used[id]; n++ {
+			id = base + "_" + strconv.Itoa(n)
+		}
+		used[id] = true
+		ids[i] = id
+	}
+	return ids
+}
+
+// buildOperation 构建单个接口操作
+func (b *Builder) buildOperation(apiDoc types.APIDoc, operationID string) *Operation {
+	operation := &Operation{
+		Summary:     apiDoc.Title,
+		Description: apiDoc.Description,
+		OperationID: operationID,
+		Responses:   make(map[string]Response),
+	}
+	// 备注和生命周期说明追加到描述中，废弃状态使用原生的 deprecated
+	for _, text := range []string{apiDoc.Remark, apiDoc.LifecycleNotice()} {
+		if text == "" {
+			continue
+		}
+		if operation.Description != "" {
+			operation.Description += "\n\n"
+		}
+		operation.Description += text
+	}
+	operation.Deprecated = apiDoc.Deprecated
+	for _, scheme := range apiDoc.Security {
+		operation.Security = append(operation.Security, map[string][]string{scheme.Name: {}})
+	}
+	if apiDoc.Catalog != "" {
+		operation.Tags = []string{apiDoc.Catalog}
+	}
+
+	// 请求参数
+	for _, param := range apiDoc.Path {
+		parameter := b.buildParameter(param, "path")
+		parameter.Required = true // 路径参数必须是必传的
+		operation.Parameters = append(operation.Parameters, parameter)
+	}
+	for _, param := range apiDoc.Header {
+		operation.Parameters = append(operation.Parameters, b.buildParameter(param, "header"))
+	}
+	for _, param := range apiDoc.Query {
+		operation.Parameters = append(operation.Parameters, b.buildParameter(param, "query"))
+	}
+
+	// 请求体
+	if len(apiDoc.FormData) > 0 {
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, param := range apiDoc.FormData {
+			property := paramSchema(param)
+			property.Description = param.Remark
+			property.Deprecated = param.Deprecated
+			schema.Properties[param.Name] = property
+			if param.Require == "true" {
+				schema.Required = append(schema.Required, param.Name)
+			}
+		}
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: schema}},
+		}
+	} else if apiDoc.BodySchema != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: b.buildSchema(apiDoc.BodySchema)}},
+		}
+	}
+
+	// 响应
+	response := Response{Description: "成功"}
+	if apiDoc.ResponseSchema != nil {
+		response.Content = map[string]MediaType{"application/json": {Schema: b.buildSchema(apiDoc.ResponseSchema)}}
+	}
+	for _, param := range apiDoc.ResponseHeader {
+		if response.Headers == nil {
+			response.Headers = make(map[string]Header)
+		}
+		response.Headers[param.Name] = Header{Description: param.Remark, Schema: primitiveSchema(param.Type)}
+	}
+	operation.Responses["200"] = response
+
+	b.buildFailures(operation, apiDoc.Failures)
+
+	return operation
+}
+
+// buildSecuritySchemes 收集接口使用的认证方式，cookie 认证导出为 in: cookie 的 apiKey
+func buildSecuritySchemes(apiDocs []types.APIDoc) map[string]*SecurityScheme {
+	schemes := make(map[string]*SecurityScheme)
+	for _, apiDoc := range apiDocs {
+		for _, scheme := range apiDoc.Security {
+			securityScheme := &SecurityScheme{Description: scheme.Description}
+			switch scheme.Type {
+			case config.SecurityBearer:
+				securityScheme.Type, securityScheme.Scheme, securityScheme.BearerFormat = "http", "bearer", scheme.BearerFormat
+			case config.SecurityBasic:
+				securityScheme.Type, securityScheme.Scheme = "http", "basic"
+			case config.SecurityAPIKey:
+				securityScheme.Type, securityScheme.In, securityScheme.Name = "apiKey", scheme.In, scheme.ParamName
+			case config.SecurityCookie:
+				securityScheme.Type, securityScheme.In, securityScheme.Name = "apiKey", "cookie", scheme.ParamName
+			}
+			schemes[scheme.Name] = securityScheme
+		}
+	}
+	return schemes
+}
+
+// buildFailures 构建失败响应，同一状态码的多个失败响应使用 oneOf 组合
+// 未指定状态码的失败响应作为 default 响应
+func (b *Builder) buildFailures(operation *Operation, failures []types.Failure) {
+	var codes []string
+	grouped := make(map[string][]types.Failure)
+	for _, failure := range failures {
+		code := "default"
+		if failure.Status != 0 {
+			code = strconv.Itoa(failure.Status)
+		}
+		if _, exists := grouped[code]; !exists {
+			codes = append(codes, code)
+		}
+		grouped[code] = append(grouped[code], failure)
+	}
+
+	for _, code := range codes {
+		var descriptions []string
+		var schemas []*Schema
+		for _, failure := range grouped[code] {
+			if failure.Description != "" {
+				descriptions = append(descriptions, failure.Description)
+			}
+			if failure.Schema != nil {
+				schemas = append(schemas, b.buildSchema(failure.Schema))
+			}
+		}
+
+		response := Response{Description: strings.Join(descriptions, "；")}
+		if response.Description == "" {
+			response.Description = "失败"
+			if status, err := strconv.Atoi(code); err == nil && http.StatusText(status) != "" {
+				response.Description = http.StatusText(status)
+			}
+		}
+
+		switch len(schemas) {
+		case 0:
+		case 1:
+			response.Content = map[string]MediaType{"application/json": {Schema: schemas[0]}}
+		default:
+			response.Content = map[string]MediaType{"application/json": {Schema: &Schema{OneOf: schemas}}}
+		}
+		operation.Responses[code] = response
+	}
+}
+
+// buildParameter 构建请求参数
+func (b *Builder) buildParameter(param types.RequestParam, in string) Parameter {
+	return Parameter{
+		Name:        param.Name,
+		In:          in,
+		Description: param.Remark,
+		Required:    param.Require == "true",
+		Deprecated:  param.Deprecated,
+		Schema:      paramSchema(param),
+	}
+}
+
+// paramSchema 构建请求参数的Schema
+func paramSchema(param types.RequestParam) *Schema {
+	schema := primitiveSchema(param.Type)
+	applyConstraints(schema, &types.Schema{Type: param.Type, Constraints: param.Constraints})
+	return schema
+}
+
+// applyConstraints 将校验约束转换为Schema关键字
+func applyConstraints(schema *Schema, node *types.Schema) {
+	c := node.Constraints
+	schema.Minimum = c.Minimum
+	schema.Maximum = c.Maximum
+	schema.ExclusiveMinimum = c.ExclusiveMinimum
+	schema.ExclusiveMaximum = c.ExclusiveMaximum
+	schema.MinLength = c.MinLength
+	schema.MaxLength = c.MaxLength
+	schema.MinItems = c.MinItems
+	schema.MaxItems = c.MaxItems
+	schema.Pattern = c.Pattern
+	schema.Enum = node.EnumValues()
+	if hasEnumRemarks(c.EnumRemarks) {
+		schema.EnumDescriptions = c.EnumRemarks
+	}
+	if c.Format != "" {
+		schema.Format = c.Format
+	}
+}
+
+// hasEnumRemarks 是否有可选值设置了说明
+func hasEnumRemarks(remarks []string) bool {
+	for _, remark := range remarks {
+		if remark != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSchema 将树形结构转换为Schema，来源于结构体的对象节点转换为组件引用
+func (b *Builder) buildSchema(node *types.Schema) *Schema {
+	var schema *Schema
+	switch node.Type {
+	case "object":
+		switch {
+		case node.Ref != "":
+			schema = b.refSchema(node)
+		case node.AdditionalProperties != nil:
+			schema = &Schema{Type: "object", AdditionalProperties: b.buildSchema(node.AdditionalProperties)}
+		default:
+			schema = b.buildObject(node)
+		}
+	case "array":
+		schema = &Schema{Type: "array", Items: &Schema{}}
+		if node.Items != nil {
+			schema.Items = b.buildSchema(node.Items)
+		}
+	case "any":
+		schema = &Schema{}
+	default:
+		schema = primitiveSchema(node.Type)
+	}
+	if node.Nullable {
+		schema = nullableSchema(schema)
+	}
+
+	// OpenAPI 3.1 允许 $ref 与 description 并存
+	schema.Description = node.Remark
+	if node.DeprecatedNote != "" {
+		schema.Description = types.DeprecatedRemark(node.Remark, node.DeprecatedNote)
+	}
+	schema.Deprecated = node.Deprecated
+	schema.Example = node.ExampleValue()
+	applyConstraints(schema, node)
+	// 可为null的可选值需要包含 null，否则 null 无法通过 enum 校验
+	if node.Nullable && len(schema.Enum) > 0 {
+		schema.Enum = append(schema.Enum, nil)
+		if schema.EnumDescriptions != nil {
+			schema.EnumDescriptions = append(schema.EnumDescriptions, "")
+		}
+	}
+	return schema
+}
+
+// nullableSchema 将指针、interface 等可为null的节点转换为允许 null 的Schema
+// 基本类型使用类型数组，如 ["string", "null"]；组件引用使用 oneOf 组合 null；任意类型本身已包含 null
+func nullableSchema(schema *Schema) *Schema {
+	switch typ := schema.Type.(type) {
+	case nil:
+		if schema.Ref != "" {
+			return &Schema{OneOf: []*Schema{schema, {Type: "null"}}}
+		}
+	case string:
+		schema.Type = []string{typ, "null"}
+	}
+	return schema
+}
+
+// buildObject 构建对象定义
+func (b *Builder) buildObject(node *types.Schema) *Schema {
+	schema := &Schema{Type: "object"}
+	for _, child := range node.Children {
+		if schema.Properties == nil {
+			schema.Properties = make(map[string]*Schema)
+		}
+		schema.Properties[child.Name] = b.buildSchema(child)
+		if child.Required {
+			schema.Required = append(schema.Required, child.Name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// refSchema 返回结构体的组件引用，首次引用时生成组件定义
+// 同一结构体作为请求体和响应时必传字段可能不同（请求体按 binding/validate 标签），
+// 必传字段不同时生成带序号后缀的组件，如 app.Item_2，每种用法都引用与之匹配的组件
+func (b *Builder) refSchema(node *types.Schema) *Schema {
+	// 循环引用或截断的节点没有子字段，引用结构体的第一个组件，尚未生成时先占位
+	if node.Circular || node.Truncated {
+		if len(b.variants[node.Ref]) == 0 {
+			name := b.addComponent(node.Ref)
+			b.partial[name] = true
+		}
+		return componentRef(b.variants[node.Ref][0])
+	}
+
+	required := requiredNames(node)
+	for _, name := range b.variants[node.Ref] {
+		if b.partial[name] {
+			b.partial[name] = false
+			b.schemas[name] = b.buildObject(node)
+			return componentRef(name)
+		}
+		if equalStrings(b.schemas[name].Required, required) {
+			return componentRef(name)
+		}
+	}
+
+	name := b.addComponent(node.Ref)
+	b.schemas[name] = b.buildObject(node)
+	return componentRef(name)
+}
+
+// addComponent 为结构体添加一个组件并返回组件名，先占位以便展开字段时引用
+// 结构体的第一个组件使用 componentName 生成的名称，之后的组件追加序号
+func (b *Builder) addComponent(structKey string) string {
+	name := b.componentName(structKey)
+	if count := len(b.variants[structKey]); count > 0 {
+		name = b.variants[structKey][0] + "_" + strconv.Itoa(count+1)
+	}
+	b.variants[structKey] = append(b.variants[structKey], name)
+	b.refs[name] = structKey
+	b.schemas[name] = &Schema{Type: "object"}
+	return name
+}
+
+// componentRef 返回组件的引用
+func componentRef(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// requiredNames 返回对象节点中必传的字段名，已排序
+func requiredNames(node *types.Schema) []string {
+	var names []string
+	for _, child := range node.Children {
+		if child.Required {
+			names = append(names, child.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// equalStrings 比较两个字符串列表
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// componentName 生成组件名，完整包路径只保留最后一级，如 example/model/user.User 生成 user.User
+// 泛型实例的实参同样去除包路径，如 response.Page[example/model/user.User] 生成 response.Page_user.User
+// 组件名只能包含字母、数字和 . - _，出现重名时使用完整包路径
+func (b *Builder) componentName(structKey string) string {
+	name := sanitizeComponentName(types.ShortTypeName(structKey))
+
+	if other, exists := b.refs[name]; exists && other != structKey {
+		name = sanitizeComponentName(structKey)
+	}
+	return name
+}
+
+// sanitizeComponentName 将组件名中不允许的字符替换为下划线，泛型实参的右括号和空格直接去除
+func sanitizeComponentName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		if r == ']' || r == ' ' {
+			return -1
+		}
+		return '_'
+	}, name)
+}
+
+// primitiveSchema 将文档参数类型转换为Schema
+// 文档类型 int 包括 Go 的 int 和 uint，在64位平台上超出 int32 的范围，因此不指定格式
+func primitiveSchema(docType string) *Schema {
+	switch docType {
+	case "string":
+		return &Schema{Type: "string"}
+	case "int":
+		return &Schema{Type: "integer"}
+	case "long":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float":
+		return &Schema{Type: "number", Format: "float"}
+	case "double":
+		return &Schema{Type: "number", Format: "double"}
+	case "number":
+		return &Schema{Type: "number"}
+	case "boolean":
+		return &Schema{Type: "boolean"}
+	case "file":
+		return &Schema{Type: "string", Format: "binary"}
+	case "array":
+		return &Schema{Type: "array", Items: &Schema{}}
+	default:
+		return &Schema{Type: "object"}
+	}
+}
+
+// normalizePath 将文档中的路由转换为OpenAPI路径，去除 {{host}} 等ShowDoc变量和协议主机部分
+func normalizePath(router string) string {
+	path := strings.TrimSpace(router)
+	for strings.HasPrefix(path, "{{") {
+		end := strings.Index(path, "}}")
+		if end == -1 {
+			break
+		}
+		path = path[end+2:]
+	}
+
+	if schemeIndex := strings.Index(path, "://"); schemeIndex != -1 {
+		path = path[schemeIndex+3:]
+		if slashIndex := strings.Index(path, "/"); slashIndex != -1 {
+			path = path[slashIndex:]
+		} else {
+			path = "/"
+		}
+	}
+
+	if queryIndex := strings.Index(path, "?"); queryIndex != -1 {
+		path = path[:queryIndex]
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	// 将 :id、*path 和 {path...} 形式的占位符转换为 {id}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case (strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*")) && len(segment) > 1:
+			segments[i] = "{" + segment[1:] + "}"
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}"):
+			segments[i] = strings.TrimSuffix(segment, "...}") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,627 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/go-runapi/pkg/config"
+	"github.com/cheivin/go-runapi/pkg/diagnostic"
+	"github.com/cheivin/go-runapi/pkg/types"
+)
+
+func TestNormalizePath(t *testing.T) {
+	tests := []struct {
+		router string
+		want   string
+	}{
+		{"/api/users", "/api/users"},
+		{"api/users", "/api/users"},
+		{"{{host}}/api/users", "/api/users"},
+		{"http://127.0.0.1:8080/api/users?page=1", "/api/users"},
+		{"https://example.com", "/"},
+		{"/api/users/:id", "/api/users/{id}"},
+		{"/static/*filepath", "/static/{filepath}"},
+		{"/files/{path...}", "/files/{path}"},
+	}
+	for _, tt := range tests {
+		if got := normalizePath(tt.router); got != tt.want {
+			t.Errorf("normalizePath(%q) = %q, want %q", tt.router, got, tt.want)
+		}
+	}
+}
+
+func TestBuildOperationIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		docs []types.APIDoc
+		want []string
+	}{
+		{
+			name: "unique function names",
+			docs: []types.APIDoc{
+				{FunctionName: "List", FilePath: "/app/user/api.go"},
+				{FunctionName: "Get", FilePath: "/app/user/api.go"},
+			},
+			want: []string{"List", "Get"},
+		},
+		{
+			name: "same name in different packages",
+			docs: []types.APIDoc{
+				{FunctionName: "List", FilePath: "/app/user/api.go"},
+				{FunctionName: "List", FilePath: "/app/order/api.go"},
+				{FunctionName: "Get", FilePath: "/app/order/api.go"},
+			},
+			want: []string{"user.List", "order.List", "Get"},
+		},
+		{
+			name: "same name in the same package",
+			docs: []types.APIDoc{
+				{FunctionName: "List", FilePath: "/app/user/a.go"},
+				{FunctionName: "List", FilePath: "/app/user/b.go"},
+				{FunctionName: "List", FilePath: "/app/user/c.go"},
+			},
+			want: []string{"user.List", "user.List_2", "user.List_3"},
+		},
+		{
+			name: "without function name",
+			docs: []types.APIDoc{{}, {FunctionName: "Get"}},
+			want: []string{"", "Get"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildOperationIDs(tt.docs)
+			if !equalStrings(got, tt.want) {
+				t.Errorf("buildOperationIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	user := &types.Schema{
+		Type: "object",
+		Ref:  "user.Info",
+		Children: []*types.Schema{
+			{Name: "id", Type: "long", Required: true},
+			{Name: "name", Type: "string", Remark: "名称"},
+		},
+	}
+	docs := []types.APIDoc{
+		{
+			Title:        "获取用户",
+			Catalog:      "用户",
+			Method:       "GET",
+			URL:          "{{host}}/users/:id",
+			FunctionName: "GetUser",
+			Path:         []types.RequestParam{{Name: "id", Type: "long", Require: "false"}},
+			Header:       []types.RequestParam{{Name: "token", Type: "string", Require: "true"}},
+			Query:        []types.RequestParam{{Name: "fields", Type: "string", Require: "false"}},
+			ResponseSchema: &types.Schema{
+				Type:     "object",
+				Children: []*types.Schema{{Name: "user", Type: "object", Ref: user.Ref, Children: user.Children}},
+			},
+			ResponseHeader: []types.ResponseParam{{Name: "X-Request-Id", Type: "string"}},
+		},
+		{
+			Title:        "更新用户",
+			Catalog:      "用户",
+			Method:       "put",
+			Router:       "/users/:id",
+			FunctionName: "UpdateUser",
+			BodySchema:   user,
+		},
+		{
+			Title:        "上传头像",
+			Catalog:      "文件",
+			Method:       "post",
+			URL:          "/avatar",
+			FunctionName: "Upload",
+			FormData:     []types.RequestParam{{Name: "file", Type: "file", Require: "true", Remark: "头像"}},
+		},
+	}
+
+	doc := NewBuilder(config.OpenAPIConfig{Title: "API", Version: "1.0.0", Servers: []string{"http://localhost"}}).Build(docs)
+
+	if doc.OpenAPI != Version || doc.Info.Title != "API" || len(doc.Servers) != 1 {
+		t.Fatalf("unexpected document header: %+v", doc)
+	}
+	if len(doc.Tags) != 2 || doc.Tags[0].Name != "用户" || doc.Tags[1].Name != "文件" {
+		t.Errorf("tags = %+v", doc.Tags)
+	}
+
+	get := doc.Paths["/users/{id}"]["get"]
+	if get == nil || doc.Paths["/users/{id}"]["put"] == nil {
+		t.Fatalf("paths = %v", doc.Paths)
+	}
+	if get.OperationID != "GetUser" || get.Summary != "获取用户" {
+		t.Errorf("operation = %+v", get)
+	}
+	wantParams := []struct {
+		name, in string
+		required bool
+	}{
+		{"id", "path", true},
+		{"token", "header", true},
+		{"fields", "query", false},
+	}
+	if len(get.Parameters) != len(wantParams) {
+		t.Fatalf("parameters = %+v", get.Parameters)
+	}
+	for i, want := range wantParams {
+		got := get.Parameters[i]
+		if got.Name != want.name || got.In != want.in || got.Required != want.required {
+			t.Errorf("parameter %d = %+v, want %+v", i, got, want)
+		}
+	}
+	if get.Parameters[0].Schema.Type != "integer" || get.Parameters[0].Schema.Format != "int64" {
+		t.Errorf("path parameter schema = %+v", get.Parameters[0].Schema)
+	}
+	if _, exists := get.Responses["200"].Headers["X-Request-Id"]; !exists {
+		t.Errorf("response headers = %+v", get.Responses["200"].Headers)
+	}
+
+	// 同一结构体在请求和响应中引用同一个组件
+	response := get.Responses["200"].Content["application/json"].Schema
+	if response.Properties["user"].Ref != "#/components/schemas/user.Info" {
+		t.Errorf("response user = %+v", response.Properties["user"])
+	}
+	put := doc.Paths["/users/{id}"]["put"]
+	if put.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/user.Info" {
+		t.Errorf("request body = %+v", put.RequestBody.Content["application/json"].Schema)
+	}
+	component := doc.Components.Schemas["user.Info"]
+	if component == nil || component.Properties["id"].Format != "int64" || !equalStrings(component.Required, []string{"id"}) {
+		t.Errorf("component = %+v", component)
+	}
+
+	upload := doc.Paths["/avatar"]["post"]
+	form := upload.RequestBody.Content["multipart/form-data"].Schema
+	if form.Properties["file"].Format != "binary" || !equalStrings(form.Required, []string{"file"}) {
+		t.Errorf("form data = %+v", form)
+	}
+}
+
+func TestBuildSchemaFieldTypes(t *testing.T) {
+	three := 3
+	node := &types.Schema{
+		Type: "object",
+		Children: []*types.Schema{
+			{Name: "labels", Type: "object", AdditionalProperties: &types.Schema{Type: "string"}},
+			{Name: "meta", Type: "any"},
+			{Name: "grid", Type: "array", Items: &types.Schema{Type: "int"}, Constraints: types.Constraints{MinItems: &three, MaxItems: &three}},
+			{Name: "owner", Type: "object", Children: []*types.Schema{{Name: "name", Type: "string", Required: true}}},
+		},
+	}
+
+	schema := NewBuilder(config.OpenAPIConfig{}).buildSchema(node)
+	if labels := schema.Properties["labels"]; labels.Type != "object" || labels.AdditionalProperties == nil || labels.AdditionalProperties.Type != "string" {
+		t.Errorf("labels = %+v", labels)
+	}
+	if meta := schema.Properties["meta"]; meta.Type != nil {
+		t.Errorf("meta = %+v, want empty schema", meta)
+	}
+	if grid := schema.Properties["grid"]; grid.MinItems == nil || *grid.MinItems != 3 || grid.MaxItems == nil || *grid.MaxItems != 3 {
+		t.Errorf("grid = %+v", grid)
+	}
+	if owner := schema.Properties["owner"]; owner.Ref != "" || !equalStrings(owner.Required, []string{"name"}) {
+		t.Errorf("owner = %+v", owner)
+	}
+}
+
+func TestBuildCircularSchema(t *testing.T) {
+	node := &types.Schema{
+		Type: "object",
+		Ref:  "app.Node",
+		Children: []*types.Schema{
+			{Name: "name", Type: "string", Required: true},
+			{Name: "children", Type: "array", Items: &types.Schema{Type: "object", Ref: "app.Node", Circular: true}},
+		},
+	}
+
+	b := NewBuilder(config.OpenAPIConfig{})
+	if schema := b.buildSchema(node); schema.Ref != "#/components/schemas/app.Node" {
+		t.Fatalf("schema = %+v", schema)
+	}
+	component := b.schemas["app.Node"]
+	if component == nil || component.Properties["name"] == nil {
+		t.Fatalf("component = %+v", component)
+	}
+	if items := component.Properties["children"].Items; items.Ref != "#/components/schemas/app.Node" {
+		t.Errorf("children items = %+v", items)
+	}
+}
+
+func TestBuildFailures(t *testing.T) {
+	errorSchema := &types.Schema{Type: "object", Children: []*types.Schema{{Name: "message", Type: "string"}}}
+	fieldSchema := &types.Schema{Type: "object", Children: []*types.Schema{{Name: "field", Type: "string"}}}
+	operation := &Operation{Responses: make(map[string]Response)}
+	NewBuilder(config.OpenAPIConfig{}).buildFailures(operation, []types.Failure{
+		{Status: 400, Description: "参数错误", Schema: errorSchema},
+		{Status: 400, Description: "字段校验失败", Schema: fieldSchema},
+		{Status: 404},
+		{Schema: errorSchema},
+	})
+
+	tests := []struct {
+		code        string
+		description string
+		oneOf       int
+		hasContent  bool
+	}{
+		{"400", "参数错误；字段校验失败", 2, true},
+		{"404", "Not Found", 0, false},
+		{"default", "失败", 0, true},
+	}
+	for _, tt := range tests {
+		response, exists := operation.Responses[tt.code]
+		if !exists {
+			t.Errorf("missing response %s", tt.code)
+			continue
+		}
+		if response.Description != tt.description || (response.Content != nil) != tt.hasContent {
+			t.Errorf("response %s = %+v", tt.code, response)
+		}
+		if tt.hasContent && len(response.Content["application/json"].Schema.OneOf) != tt.oneOf {
+			t.Errorf("response %s oneOf = %+v", tt.code, response.Content["application/json"].Schema.OneOf)
+		}
+	}
+}
+
+func TestBuildRequiredDiffers(t *testing.T) {
+	request := &types.Schema{
+		Type: "object",
+		Ref:  "app.Item",
+		Children: []*types.Schema{
+			{Name: "name", Type: "string", Required: true},
+			{Name: "note", Type: "string"},
+		},
+	}
+	response := &types.Schema{
+		Type: "object",
+		Ref:  "app.Item",
+		Children: []*types.Schema{
+			{Name: "name", Type: "string", Required: true},
+			{Name: "note", Type: "string", Required: true},
+		},
+	}
+
+	// 必传字段不同的同一结构体生成带序号后缀的组件，相同的用法复用同一组件
+	b := NewBuilder(config.OpenAPIConfig{})
+	tests := []struct {
+		node     *types.Schema
+		ref      string
+		required []string
+	}{
+		{request, "#/components/schemas/app.Item", []string{"name"}},
+		{response, "#/components/schemas/app.Item_2", []string{"name", "note"}},
+		{request, "#/components/schemas/app.Item", []string{"name"}},
+		{response, "#/components/schemas/app.Item_2", []string{"name", "note"}},
+	}
+	for i, tt := range tests {
+		schema := b.buildSchema(tt.node)
+		if schema.Ref != tt.ref {
+			t.Errorf("schema %d ref = %s, want %s", i, schema.Ref, tt.ref)
+			continue
+		}
+		name := strings.TrimPrefix(tt.ref, "#/components/schemas/")
+		if component := b.schemas[name]; !equalStrings(component.Required, tt.required) {
+			t.Errorf("component %s required = %v, want %v", name, component.Required, tt.required)
+		}
+	}
+}
+
+func TestParamSchemaConstraints(t *testing.T) {
+	one, hundred, eight := 1.0, 100.0, 8
+	tests := []struct {
+		name  string
+		param types.RequestParam
+		check func(schema *Schema) bool
+	}{
+		{"range", types.RequestParam{Type: "int", Constraints: types.Constraints{Minimum: &one, ExclusiveMaximum: &hundred}}, func(s *Schema) bool {
+			return *s.Minimum == 1 && *s.ExclusiveMaximum == 100 && s.Maximum == nil
+		}},
+		{"length and pattern", types.RequestParam{Type: "string", Constraints: types.Constraints{MaxLength: &eight, Pattern: "^[a-z]+$"}}, func(s *Schema) bool {
+			return *s.MaxLength == 8 && s.Pattern == "^[a-z]+$"
+		}},
+		{"format overrides type format", types.RequestParam{Type: "string", Constraints: types.Constraints{Format: "email"}}, func(s *Schema) bool {
+			return s.Type == "string" && s.Format == "email"
+		}},
+		{"enum", types.RequestParam{Type: "string", Constraints: types.Constraints{Enum: []string{"asc", "desc"}}}, func(s *Schema) bool {
+			return len(s.Enum) == 2 && s.Enum[0] == "asc" && s.EnumDescriptions == nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if schema := paramSchema(tt.param); !tt.check(schema) {
+				t.Errorf("paramSchema() = %+v", schema)
+			}
+		})
+	}
+}
+
+func TestBuildSchemaEnum(t *testing.T) {
+	tests := []struct {
+		name         string
+		node         *types.Schema
+		enum         []any
+		descriptions []string
+	}{
+		{
+			name:         "typed constants",
+			node:         &types.Schema{Type: "int", Constraints: types.Constraints{Enum: []string{"0", "1"}, EnumRemarks: []string{"未知", "启用"}}},
+			enum:         []any{int64(0), int64(1)},
+			descriptions: []string{"未知", "启用"},
+		},
+		{
+			name: "without remarks",
+			node: &types.Schema{Type: "string", Constraints: types.Constraints{Enum: []string{"a", "b"}, EnumRemarks: []string{"", ""}}},
+			enum: []any{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := NewBuilder(config.OpenAPIConfig{}).buildSchema(tt.node)
+			if len(schema.Enum) != len(tt.enum) {
+				t.Fatalf("enum = %v, want %v", schema.Enum, tt.enum)
+			}
+			for i := range tt.enum {
+				if schema.Enum[i] != tt.enum[i] {
+					t.Errorf("enum[%d] = %#v, want %#v", i, schema.Enum[i], tt.enum[i])
+				}
+			}
+			if !equalStrings(schema.EnumDescriptions, tt.descriptions) {
+				t.Errorf("x-enum-descriptions = %v, want %v", schema.EnumDescriptions, tt.descriptions)
+			}
+		})
+	}
+}
+
+func TestBuildSchemaFormats(t *testing.T) {
+	tests := []struct {
+		node   *types.Schema
+		typ    any
+		format string
+	}{
+		{&types.Schema{Type: "string", Constraints: types.Constraints{Format: "date-time"}}, "string", "date-time"},
+		{&types.Schema{Type: "string", Constraints: types.Constraints{Format: "uuid"}}, "string", "uuid"},
+		{&types.Schema{Type: "long"}, "integer", "int64"},
+		{&types.Schema{Type: "long", Constraints: types.Constraints{Format: "cents"}}, "integer", "cents"},
+		{&types.Schema{Type: "any"}, nil, ""},
+	}
+	b := NewBuilder(config.OpenAPIConfig{})
+	for _, tt := range tests {
+		if schema := b.buildSchema(tt.node); schema.Type != tt.typ || schema.Format != tt.format {
+			t.Errorf("buildSchema(%s %s) = {%v %s}, want {%v %s}", tt.node.Type, tt.node.Format, schema.Type, schema.Format, tt.typ, tt.format)
+		}
+	}
+}
+
+func TestBuildOperationDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  types.APIDoc
+		want string
+	}{
+		{"description only", types.APIDoc{Description: "第一行\n第二行"}, "第一行\n第二行"},
+		{"remark only", types.APIDoc{Remark: "- 列表"}, "- 列表"},
+		{"description and remark", types.APIDoc{Description: "描述", Remark: "```\n@code\n```"}, "描述\n\n```\n@code\n```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doc.Method, tt.doc.URL = "get", "/users"
+			if got := NewBuilder(config.OpenAPIConfig{}).buildOperation(tt.doc, "").Description; got != tt.want {
+				t.Errorf("description = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildDeprecated(t *testing.T) {
+	doc := types.APIDoc{
+		Title:          "用户列表",
+		Method:         "get",
+		URL:            "/users",
+		Description:    "查询用户",
+		Deprecated:     true,
+		DeprecatedNote: "请使用 /v2/users",
+		Sunset:         "2027-01-01",
+		Query:          []types.RequestParam{{Name: "q", Type: "string", Require: "false", Deprecated: true}},
+		ResponseSchema: &types.Schema{
+			Type: "object",
+			Children: []*types.Schema{
+				{Name: "name", Type: "string", Remark: "名称", Deprecated: true, DeprecatedNote: "使用 nickname"},
+				{Name: "nickname", Type: "string"},
+			},
+		},
+	}
+
+	operation := NewBuilder(config.OpenAPIConfig{}).buildOperation(doc, "ListUsers")
+	if !operation.Deprecated || operation.Description != "查询用户\n\n已废弃：请使用 /v2/users；计划下线：2027-01-01" {
+		t.Errorf("operation = {deprecated: %t, description: %q}", operation.Deprecated, operation.Description)
+	}
+	if len(operation.Parameters) != 1 || !operation.Parameters[0].Deprecated {
+		t.Errorf("parameters = %+v", operation.Parameters)
+	}
+	response := operation.Responses["200"].Content["application/json"].Schema
+	if name := response.Properties["name"]; !name.Deprecated || name.Description != "名称（已废弃：使用 nickname）" {
+		t.Errorf("name = %+v", name)
+	}
+	if response.Properties["nickname"].Deprecated {
+		t.Errorf("nickname = %+v", response.Properties["nickname"])
+	}
+}
+
+func TestBuildSecurity(t *testing.T) {
+	docs := []types.APIDoc{
+		{
+			Title:  "导出",
+			Method: "get",
+			URL:    "/export",
+			Security: []types.SecurityScheme{
+				{Name: "bearer", Type: "bearer", BearerFormat: "JWT"},
+				{Name: "session", Type: "cookie", ParamName: "SESSIONID"},
+			},
+		},
+		{
+			Title:  "回调",
+			Method: "post",
+			URL:    "/hook",
+			Security: []types.SecurityScheme{
+				{Name: "apikey", Type: "apiKey", In: "query", ParamName: "token"},
+				{Name: "basic", Type: "basic"},
+			},
+		},
+		{Title: "登录", Method: "post", URL: "/login"},
+	}
+
+	doc := NewBuilder(config.OpenAPIConfig{}).Build(docs)
+	tests := []struct {
+		name string
+		want SecurityScheme
+	}{
+		{"bearer", SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}},
+		{"session", SecurityScheme{Type: "apiKey", In: "cookie", Name: "SESSIONID"}},
+		{"apikey", SecurityScheme{Type: "apiKey", In: "query", Name: "token"}},
+		{"basic", SecurityScheme{Type: "http", Scheme: "basic"}},
+	}
+	if doc.Components == nil || len(doc.Components.SecuritySchemes) != len(tests) {
+		t.Fatalf("components = %+v", doc.Components)
+	}
+	for _, tt := range tests {
+		if got := doc.Components.SecuritySchemes[tt.name]; got == nil || *got != tt.want {
+			t.Errorf("securitySchemes[%s] = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	// 接口的多个认证方式为可选其一的认证要求
+	export := doc.Paths["/export"]["get"]
+	if len(export.Security) != 2 || export.Security[0]["bearer"] == nil || export.Security[1]["session"] == nil {
+		t.Errorf("export security = %v", export.Security)
+	}
+	if login := doc.Paths["/login"]["post"]; login.Security != nil {
+		t.Errorf("login security = %v", login.Security)
+	}
+}
+
+func TestBuildEnvelope(t *testing.T) {
+	info := &types.Schema{Name: "data", Type: "object", Ref: "user.Info", Remark: "业务数据", Children: []*types.Schema{{Name: "id", Type: "long"}}}
+	envelopeFields := func(data *types.Schema) []*types.Schema {
+		return []*types.Schema{{Name: "code", Type: "int"}, {Name: "message", Type: "string"}, data}
+	}
+	// 统一响应结构包装后的响应清除了 Ref，内联展开，业务数据字段引用组件
+	wrapped := &types.Schema{Type: "object", GoType: "response.Response{data=user.Info}", Children: envelopeFields(info)}
+	envelope := &types.Schema{Type: "object", Ref: "response.Response", Children: envelopeFields(&types.Schema{Name: "data", Type: "any", Remark: "业务数据"})}
+
+	b := NewBuilder(config.OpenAPIConfig{})
+	schema := b.buildSchema(wrapped)
+	if schema.Ref != "" || len(schema.Properties) != 3 {
+		t.Fatalf("wrapped schema = %+v", schema)
+	}
+	if data := schema.Properties["data"]; data.Ref != "#/components/schemas/user.Info" || data.Description != "业务数据" {
+		t.Errorf("data = %+v", data)
+	}
+
+	// 没有结构体的失败响应使用统一响应结构体本身，生成组件
+	if schema := b.buildSchema(envelope); schema.Ref != "#/components/schemas/response.Response" {
+		t.Errorf("envelope schema = %+v", schema)
+	}
+	component := b.schemas["response.Response"]
+	if data := component.Properties["data"]; data == nil || data.Type != nil || data.Ref != "" {
+		t.Errorf("component data = %+v", data)
+	}
+	if _, exists := b.schemas["user.Info"]; !exists {
+		t.Errorf("schemas = %v, want user.Info", b.schemas)
+	}
+}
+
+func TestPrimitiveSchema(t *testing.T) {
+	tests := []struct {
+		docType string
+		typ     string
+		format  string
+	}{
+		// int 包括 Go 的 int 和 uint，不限定为 int32
+		{"int", "integer", ""},
+		{"long", "integer", "int64"},
+		{"float", "number", "float"},
+		{"double", "number", "double"},
+		{"boolean", "boolean", ""},
+		{"file", "string", "binary"},
+		{"string", "string", ""},
+	}
+	for _, tt := range tests {
+		if schema := primitiveSchema(tt.docType); schema.Type != tt.typ || schema.Format != tt.format {
+			t.Errorf("primitiveSchema(%s) = {%v %s}, want {%s %s}", tt.docType, schema.Type, schema.Format, tt.typ, tt.format)
+		}
+	}
+}
+
+func TestBuildNullable(t *testing.T) {
+	node := &types.Schema{
+		Type: "object",
+		Children: []*types.Schema{
+			{Name: "name", Type: "string", Nullable: true, Remark: "名称"},
+			{Name: "tags", Type: "array", Nullable: true, Items: &types.Schema{Type: "string"}},
+			{Name: "status", Type: "int", Nullable: true, Constraints: types.Constraints{Enum: []string{"1", "2"}, EnumRemarks: []string{"启用", "禁用"}}},
+			{Name: "owner", Type: "object", Ref: "app.User", Nullable: true, Remark: "负责人", Children: []*types.Schema{{Name: "id", Type: "long"}}},
+			{Name: "meta", Type: "any", Nullable: true},
+			{Name: "id", Type: "long"},
+		},
+	}
+
+	schema := NewBuilder(config.OpenAPIConfig{}).buildSchema(node)
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"name", `{"type":["string","null"],"description":"名称"}`},
+		{"tags", `{"type":["array","null"],"items":{"type":"string"}}`},
+		{"status", `{"type":["integer","null"],"enum":[1,2,null],"x-enum-descriptions":["启用","禁用",""]}`},
+		{"owner", `{"description":"负责人","oneOf":[{"$ref":"#/components/schemas/app.User"},{"type":"null"}]}`},
+		{"meta", `{}`},
+		{"id", `{"type":"integer","format":"int64"}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(schema.Properties[tt.field])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s = %s, want %s", tt.field, data, tt.want)
+		}
+	}
+}
+
+func TestBuildDuplicateRoutes(t *testing.T) {
+	docs := []types.APIDoc{
+		{Title: "用户列表", Method: "get", URL: "/users", FunctionName: "ListUsers", FilePath: "/app/user/api.go", Line: 10},
+		{Title: "创建用户", Method: "post", URL: "/users", FunctionName: "CreateUser", FilePath: "/app/user/api.go", Line: 20},
+		{Title: "搜索用户", Method: "GET", URL: "{{host}}/users", FunctionName: "SearchUsers", FilePath: "/app/user/search.go", Line: 5},
+	}
+
+	b := NewBuilder(config.OpenAPIConfig{})
+	doc := b.Build(docs)
+	// 重复的接口保留第一个
+	if get := doc.Paths["/users"]["get"]; get == nil || get.Summary != "用户列表" || doc.Paths["/users"]["post"] == nil {
+		t.Errorf("paths = %+v", doc.Paths["/users"])
+	}
+
+	diagnostics := b.Diagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("diagnostics = %v", diagnostics)
+	}
+	d := diagnostics[0]
+	if d.Code != diagnostic.CodeDuplicateRoute || d.Position() != "/app/user/search.go:5" ||
+		d.Message != "接口 搜索用户 的路由 GET /users 与接口 用户列表 重复，OpenAPI文档中已忽略" {
+		t.Errorf("diagnostic = %s", d)
+	}
+
+	// 每次构建重新收集诊断信息
+	b = NewBuilder(config.OpenAPIConfig{})
+	b.Build(docs[:2])
+	if diagnostics := b.Diagnostics(); len(diagnostics) != 0 {
+		t.Errorf("diagnostics = %v", diagnostics)
+	}
+}
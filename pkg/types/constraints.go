@@ -0,0 +1,152 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraints 参数校验约束，来自 validate/binding 标签和 enums 标签
+// 数值的取值范围、字符串长度和数组元素个数分别使用不同的字段
+type Constraints struct {
+	Minimum          *float64 `json:"minimum,omitempty"`           // 最小值（包含）
+	Maximum          *float64 `json:"maximum,omitempty"`           // 最大值（包含）
+	ExclusiveMinimum *float64 `json:"exclusive_minimum,omitempty"` // 最小值（不包含）
+	ExclusiveMaximum *float64 `json:"exclusive_maximum,omitempty"` // 最大值（不包含）
+	MinLength        *int     `json:"min_length,omitempty"`        // 字符串最小长度
+	MaxLength        *int     `json:"max_length,omitempty"`        // 字符串最大长度
+	MinItems         *int     `json:"min_items,omitempty"`         // 数组最少元素个数
+	MaxItems         *int     `json:"max_items,omitempty"`         // 数组最多元素个数
+	Pattern          string   `json:"pattern,omitempty"`           // 正则表达式
+	Format           string   `json:"format,omitempty"`            // 格式，如 email、uri、uuid
+	Enum             []string `json:"enum,omitempty"`              // 可选值
+	EnumRemarks      []string `json:"enum_remarks,omitempty"`      // 可选值的说明，与 Enum 一一对应
+}
+
+// IsZero 是否没有任何约束
+func (c Constraints) IsZero() bool {
+	return c.Minimum == nil && c.Maximum == nil && c.ExclusiveMinimum == nil && c.ExclusiveMaximum == nil &&
+		c.MinLength == nil && c.MaxLength == nil && c.MinItems == nil && c.MaxItems == nil &&
+		c.Pattern == "" && c.Format == "" && len(c.Enum) == 0
+}
+
+// Merge 使用 other 补充未设置的约束
+func (c Constraints) Merge(other Constraints) Constraints {
+	if c.Minimum == nil {
+		c.Minimum = other.Minimum
+	}
+	if c.Maximum == nil {
+		c.Maximum = other.Maximum
+	}
+	if c.ExclusiveMinimum == nil {
+		c.ExclusiveMinimum = other.ExclusiveMinimum
+	}
+	if c.ExclusiveMaximum == nil {
+		c.ExclusiveMaximum = other.ExclusiveMaximum
+	}
+	if c.MinLength == nil {
+		c.MinLength = other.MinLength
+	}
+	if c.MaxLength == nil {
+		c.MaxLength = other.MaxLength
+	}
+	if c.MinItems == nil {
+		c.MinItems = other.MinItems
+	}
+	if c.MaxItems == nil {
+		c.MaxItems = other.MaxItems
+	}
+	if c.Pattern == "" {
+		c.Pattern = other.Pattern
+	}
+	if c.Format == "" {
+		c.Format = other.Format
+	}
+	if len(c.Enum) == 0 {
+		c.Enum, c.EnumRemarks = other.Enum, other.EnumRemarks
+	}
+	return c
+}
+
+// Describe 将约束描述为文字，用于追加到参数备注，如 "取值 1~100，可选值 a/b/c"
+func (c Constraints) Describe() string {
+	var parts []string
+
+	if value := describeRange(c.Minimum, c.ExclusiveMinimum, c.Maximum, c.ExclusiveMaximum); value != "" {
+		parts = append(parts, "取值 "+value)
+	}
+	if value := describeRange(intToFloat(c.MinLength), nil, intToFloat(c.MaxLength), nil); value != "" {
+		parts = append(parts, "长度 "+value)
+	}
+	if value := describeRange(intToFloat(c.MinItems), nil, intToFloat(c.MaxItems), nil); value != "" {
+		parts = append(parts, "元素个数 "+value)
+	}
+	if len(c.Enum) > 0 {
+		parts = append(parts, "可选值 "+strings.Join(c.enumItems(), "/"))
+	}
+	if c.Format != "" {
+		parts = append(parts, "格式 "+c.Format)
+	}
+	if c.Pattern != "" {
+		parts = append(parts, "匹配 "+c.Pattern)
+	}
+
+	return strings.Join(parts, "，")
+}
+
+// enumItems 返回可选值的描述，有说明的可选值写作 "值=说明"
+func (c Constraints) enumItems() []string {
+	items := make([]string, len(c.Enum))
+	for i, enum := range c.Enum {
+		items[i] = enum
+		if i < len(c.EnumRemarks) && c.EnumRemarks[i] != "" {
+			items[i] = enum + "=" + c.EnumRemarks[i]
+		}
+	}
+	return items
+}
+
+// describeRange 描述取值范围，如 1~100、≥1、<100、(0,100]
+func describeRange(min, exclusiveMin, max, exclusiveMax *float64) string {
+	switch {
+	case min == nil && exclusiveMin == nil && max == nil && exclusiveMax == nil:
+		return ""
+	case min != nil && max != nil:
+		if *min == *max {
+			return formatNumber(*min)
+		}
+		return formatNumber(*min) + "~" + formatNumber(*max)
+	case (min != nil || exclusiveMin != nil) && (max != nil || exclusiveMax != nil):
+		left, right := "[", "]"
+		lower, upper := min, max
+		if exclusiveMin != nil {
+			left, lower = "(", exclusiveMin
+		}
+		if exclusiveMax != nil {
+			right, upper = ")", exclusiveMax
+		}
+		return fmt.Sprintf("%s%s,%s%s", left, formatNumber(*lower), formatNumber(*upper), right)
+	case min != nil:
+		return "≥" + formatNumber(*min)
+	case exclusiveMin != nil:
+		return ">" + formatNumber(*exclusiveMin)
+	case max != nil:
+		return "≤" + formatNumber(*max)
+	default:
+		return "<" + formatNumber(*exclusiveMax)
+	}
+}
+
+// formatNumber 格式化数字，整数不带小数点
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// intToFloat 将整数指针转换为浮点数指针
+func intToFloat(value *int) *float64 {
+	if value == nil {
+		return nil
+	}
+	f := float64(*value)
+	return &f
+}
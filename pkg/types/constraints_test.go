@@ -0,0 +1,38 @@
+package types
+
+import "testing"
+
+func TestConstraintsDescribe(t *testing.T) {
+	one, two, ten, hundred := 1.0, 2, 10, 100.0
+	zero := 0.0
+	tests := []struct {
+		name        string
+		constraints Constraints
+		want        string
+	}{
+		{"empty", Constraints{}, ""},
+		{"range", Constraints{Minimum: &one, Maximum: &hundred}, "取值 1~100"},
+		{"exclusive", Constraints{Minimum: &zero, ExclusiveMaximum: &hundred}, "取值 [0,100)"},
+		{"lower bound", Constraints{ExclusiveMinimum: &zero}, "取值 >0"},
+		{"upper bound", Constraints{Maximum: &hundred}, "取值 ≤100"},
+		{"fixed length", Constraints{MinLength: &ten, MaxLength: &ten}, "长度 10"},
+		{"items", Constraints{MinItems: &two}, "元素个数 ≥2"},
+		{"enum", Constraints{Enum: []string{"1", "2"}, EnumRemarks: []string{"启用", ""}}, "可选值 1=启用/2"},
+		{"format and pattern", Constraints{Format: "email", Pattern: "^a+$"}, "格式 email，匹配 ^a+$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.constraints.Describe(); got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintsMerge(t *testing.T) {
+	one, two := 1, 2
+	c := Constraints{MinItems: &one, Format: "email"}.Merge(Constraints{MinItems: &two, MaxItems: &two, Format: "uri", Enum: []string{"a"}})
+	if *c.MinItems != 1 || *c.MaxItems != 2 || c.Format != "email" || len(c.Enum) != 1 {
+		t.Errorf("Merge() = %+v", c)
+	}
+}
@@ -0,0 +1,157 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// formatExamples 字符串格式对应的默认示例值
+var formatExamples = map[string]string{
+	"date-time": "2006-01-02T15:04:05Z",
+	"date":      "2006-01-02",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"decimal":   "0.00",
+	"email":     "user@example.com",
+	"uri":       "https://example.com",
+	"ipv4":      "127.0.0.1",
+	"ipv6":      "::1",
+	"byte":      "U3dhZ2dlciByb2Nrcw==",
+	"int64":     "0",
+}
+
+// ExampleJSON 根据树形结构生成格式化的JSON示例
+// 字段值优先使用 example 标签，其次使用第一个枚举值，否则使用类型的默认示例值
+func (s *Schema) ExampleJSON() string {
+	data, err := json.MarshalIndent(s.exampleValue(), "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// ExampleValue 返回 example 标签转换为字段类型后的值，没有设置时返回nil
+func (s *Schema) ExampleValue() any {
+	if s.Example == "" {
+		return nil
+	}
+
+	// 数组的示例值使用逗号分隔，如 example:"1,2,3"
+	if s.Type == "array" && s.Items != nil && !json.Valid([]byte(s.Example)) {
+		var values []any
+		for _, part := range strings.Split(s.Example, ",") {
+			values = append(values, s.Items.scalarValue(strings.TrimSpace(part)))
+		}
+		return values
+	}
+	return s.scalarValue(s.Example)
+}
+
+// EnumValues 返回转换为字段类型后的枚举值
+func (s *Schema) EnumValues() []any {
+	var values []any
+	for _, enum := range s.Enum {
+		values = append(values, s.scalarValue(enum))
+	}
+	return values
+}
+
+// exampleValue 递归生成节点的示例值
+func (s *Schema) exampleValue() any {
+	if value := s.ExampleValue(); value != nil {
+		return value
+	}
+	if len(s.Enum) > 0 {
+		return s.scalarValue(s.Enum[0])
+	}
+
+	switch s.Type {
+	case "object":
+		if s.AdditionalProperties != nil {
+			return orderedObject{{Key: "key", Value: s.AdditionalProperties.exampleValue()}}
+		}
+		object := orderedObject{}
+		for _, child := range s.Children {
+			object = append(object, orderedField{Key: child.Name, Value: child.exampleValue()})
+		}
+		return object
+	case "array":
+		// 循环引用的元素不再展开，避免示例无限嵌套
+		if s.Items == nil || s.Items.Circular {
+			return []any{}
+		}
+		return []any{s.Items.exampleValue()}
+	case "string":
+		if example, exists := formatExamples[s.Format]; exists {
+			return example
+		}
+		return "string"
+	case "int", "long":
+		return 0
+	case "float", "double", "number":
+		return 0.0
+	case "boolean":
+		return false
+	case "file":
+		return "file"
+	default:
+		return nil
+	}
+}
+
+// scalarValue 将字符串转换为节点类型对应的值，转换失败时保留字符串
+func (s *Schema) scalarValue(raw string) any {
+	switch s.Type {
+	case "int", "long":
+		if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return value
+		}
+	case "float", "double", "number":
+		if value, err := strconv.ParseFloat(raw, 64); err == nil {
+			return value
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(raw); err == nil {
+			return value
+		}
+	case "object", "array", "any":
+		if json.Valid([]byte(raw)) {
+			return json.RawMessage(raw)
+		}
+	}
+	return raw
+}
+
+// orderedField 有序对象的字段
+type orderedField struct {
+	Key   string
+	Value any
+}
+
+// orderedObject 按字段定义顺序序列化的JSON对象
+type orderedObject []orderedField
+
+// MarshalJSON 按字段顺序输出JSON对象
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
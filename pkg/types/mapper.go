@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"strconv"
 	"strings"
 )
 
@@ -16,6 +17,20 @@ func APIDocToPageContent(apiDoc APIDoc) PageContent {
 	// 转换请求参数
 	headers := convertRequestParams(apiDoc.Header)
 	query := convertRequestParams(apiDoc.Query)
+	pathVariable := convertRequestParams(apiDoc.Path)
+
+	// 认证方式，apiKey 和 cookie 认证作为请求头、查询参数或 cookie 展示
+	// 已声明同名请求头或查询参数时（如 @param token header）不再重复添加
+	auth, authParam, cookies := convertSecurity(apiDoc.Security)
+	if authParam != nil {
+		if authParam.Location == "query" {
+			if !hasParam(query, authParam.Name, false) {
+				query = append(query, authParam.Param)
+			}
+		} else if !hasParam(headers, authParam.Name, true) {
+			headers = append(headers, authParam.Param)
+		}
+	}
 
 	// 确定请求参数模式
 	var params Params
@@ -25,6 +40,9 @@ func APIDocToPageContent(apiDoc APIDoc) PageContent {
 	} else if len(apiDoc.Body) > 0 {
 		params.Mode = "json"
 		params.JSONDesc = convertRequestParams(apiDoc.Body)
+		if apiDoc.BodySchema != nil {
+			params.JSON = apiDoc.BodySchema.ExampleJSON()
+		}
 	} else {
 		params.Mode = "formdata"
 		params.FormData = []Param{}
@@ -47,26 +65,131 @@ func APIDocToPageContent(apiDoc APIDoc) PageContent {
 		responseParamsDesc = append(responseParamsDesc, headerParams...)
 	}
 
+	// 根据响应体结构生成响应示例
+	var responseExample string
+	if apiDoc.ResponseSchema != nil {
+		responseExample = apiDoc.ResponseSchema.ExampleJSON()
+	}
+
+	failExample, failParamsDesc := convertFailures(apiDoc.Failures)
+
+	// 生命周期说明放在备注的开头，废弃的接口同时设置接口状态
+	remark := apiDoc.Remark
+	if notice := apiDoc.LifecycleNotice(); notice != "" {
+		remark = strings.TrimSpace("> " + notice + "\n\n" + remark)
+	}
+	apiStatus := APIStatusNone
+	if apiDoc.Deprecated {
+		apiStatus = APIStatusDeprecated
+	}
+
+	// ShowDoc只能设置一种认证方式，其余的认证方式追加到接口描述中
+	description := apiDoc.Description
+	if others := describeSecurity(apiDoc.Security); others != "" {
+		description = strings.TrimSpace(description + "\n\n" + others)
+	}
+
 	return PageContent{
 		PageTitle: apiDoc.Title,
 		Info: Info{
 			Title:       apiDoc.Title,
-			Description: apiDoc.Description,
+			Description: description,
 			Method:      apiDoc.Method,
 			URL:         url,
+			Remark:      remark,
+			APIStatus:   apiStatus,
 		},
 		Request: Request{
-			Params:  params,
-			Headers: headers,
-			Query:   query,
+			Params:       params,
+			Headers:      headers,
+			Query:        query,
+			PathVariable: pathVariable,
+			Cookies:      cookies,
+			Auth:         auth,
 		},
 		Response: Response{
-			ResponseParamsDesc: responseParamsDesc,
-			Remark:             apiDoc.Remark,
+			ResponseParamsDesc:     responseParamsDesc,
+			ResponseExample:        responseExample,
+			ResponseFailExample:    failExample,
+			ResponseFailParamsDesc: failParamsDesc,
+			Remark:                 remark,
 		},
 	}
 }
 
+// securityParam apiKey 认证对应的请求参数
+type securityParam struct {
+	Param
+	Location string // header、query
+}
+
+// convertSecurity 将接口的第一个认证方式转换为ShowDoc的认证信息，没有认证方式时认证类型为 none
+// 其余的认证方式由 describeSecurity 写入接口描述
+// bearer 和 basic 使用ShowDoc的认证设置，apiKey 转换为必传的请求头或查询参数，cookie 转换为 cookie
+func convertSecurity(schemes []SecurityScheme) (*Auth, *securityParam, []Cookie) {
+	auth := &Auth{Type: "none", Disabled: "0"}
+	if len(schemes) == 0 {
+		return auth, nil, nil
+	}
+
+	scheme := schemes[0]
+	switch scheme.Type {
+	case "bearer":
+		auth.Type, auth.Bearer = "bearer", &AuthBearer{}
+	case "basic":
+		auth.Type, auth.Basic = "basic", &AuthBasic{}
+	case "apiKey":
+		remark := scheme.Description
+		if remark == "" {
+			remark = "认证信息"
+		}
+		return auth, &securityParam{
+			Param:    Param{Name: scheme.ParamName, Type: "string", Require: "1", Remark: remark},
+			Location: scheme.In,
+		}, nil
+	case "cookie":
+		return auth, nil, []Cookie{{Name: scheme.ParamName}}
+	}
+	return auth, nil, nil
+}
+
+// describeSecurity 返回第一个之外的认证方式的说明，如 "也可使用以下认证方式：session（cookie SESSIONID）"
+func describeSecurity(schemes []SecurityScheme) string {
+	if len(schemes) < 2 {
+		return ""
+	}
+	descriptions := make([]string, 0, len(schemes)-1)
+	for _, scheme := range schemes[1:] {
+		detail := scheme.Type
+		switch scheme.Type {
+		case "apiKey":
+			detail += " " + scheme.In + " " + scheme.ParamName
+		case "cookie":
+			detail += " " + scheme.ParamName
+		case "bearer":
+			if scheme.BearerFormat != "" {
+				detail += " " + scheme.BearerFormat
+			}
+		}
+		description := scheme.Name + "（" + detail + "）"
+		if scheme.Description != "" {
+			description += " " + scheme.Description
+		}
+		descriptions = append(descriptions, description)
+	}
+	return "也可使用以下认证方式：" + strings.Join(descriptions, "、")
+}
+
+// hasParam 检查参数列表中是否已有同名参数，请求头名称不区分大小写
+func hasParam(params []Param, name string, ignoreCase bool) bool {
+	for _, param := range params {
+		if param.Name == name || ignoreCase && strings.EqualFold(param.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // convertRequestParams 转换请求参数
 func convertRequestParams(params []RequestParam) []Param {
 	result := make([]Param, 0)
@@ -77,7 +200,7 @@ func convertRequestParams(params []RequestParam) []Param {
 			Value:   "",
 			Type:    p.Type,
 			Require: p.Require,
-			Remark:  p.Remark,
+			Remark:  remarkWithConstraints(p.Remark, p.Constraints),
 		}
 		if param.Require == "true" {
 			param.Require = "1"
@@ -89,6 +212,18 @@ func convertRequestParams(params []RequestParam) []Param {
 	return result
 }
 
+// remarkWithConstraints 在备注后追加校验约束的描述，如 "页码（取值 1~100）"
+func remarkWithConstraints(remark string, constraints Constraints) string {
+	description := constraints.Describe()
+	if description == "" {
+		return remark
+	}
+	if remark == "" {
+		return description
+	}
+	return remark + "（" + description + "）"
+}
+
 // convertResponseParams 转换响应参数
 func convertResponseParams(params []ResponseParam) []ResponseParamDesc {
 	var result []ResponseParamDesc
@@ -102,6 +237,41 @@ func convertResponseParams(params []ResponseParam) []ResponseParamDesc {
 	return result
 }
 
+// convertFailures 将失败响应转换为失败示例和参数说明
+// 多个失败响应的示例依次排列，并以状态码和描述作为注释；参数按名称去重
+func convertFailures(failures []Failure) (string, []ResponseParamDesc) {
+	var examples []string
+	var paramsDesc []ResponseParamDesc
+	seen := make(map[string]bool)
+
+	for _, failure := range failures {
+		var example strings.Builder
+		if len(failures) > 1 {
+			title := "失败"
+			if failure.Status != 0 {
+				title = strconv.Itoa(failure.Status)
+			}
+			example.WriteString(strings.TrimSpace("// " + title + " " + failure.Description))
+			example.WriteString("\n")
+		}
+		if failure.Schema != nil {
+			example.WriteString(failure.Schema.ExampleJSON())
+		}
+		if example.Len() > 0 {
+			examples = append(examples, strings.TrimSpace(example.String()))
+		}
+
+		for _, param := range convertResponseParams(failure.Body) {
+			if !seen[param.Name] {
+				seen[param.Name] = true
+				paramsDesc = append(paramsDesc, param)
+			}
+		}
+	}
+
+	return strings.Join(examples, "\n\n"), paramsDesc
+}
+
 // convertResponseParamsWithRemark 转换响应参数并添加备注
 func convertResponseParamsWithRemark(params []ResponseParam, remarkPrefix string) []ResponseParamDesc {
 	var result []ResponseParamDesc
@@ -130,6 +300,14 @@ func MergeWithFullContent(base PageContent, full PageContentFull) PageContentFul
 	full.Info.Description = base.Info.Description
 	full.Info.Method = base.Info.Method
 	full.Info.URL = base.Info.URL
+	// 没有在代码中声明备注时保留页面上已有的备注
+	if base.Info.Remark != "" {
+		full.Info.Remark = base.Info.Remark
+	}
+	// 废弃的接口设置为已废弃状态，取消废弃时恢复为未设置，保留页面上手动设置的其他状态
+	if base.Info.APIStatus == APIStatusDeprecated || full.Info.APIStatus == APIStatusDeprecated {
+		full.Info.APIStatus = base.Info.APIStatus
+	}
 
 	// 更新请求结构
 	full.Request.Params.Mode = base.Request.Params.Mode
@@ -138,9 +316,18 @@ func MergeWithFullContent(base PageContent, full PageContentFull) PageContentFul
 	full.Request.Params.JSONDesc = base.Request.Params.JSONDesc
 	full.Request.Headers = base.Request.Headers
 	full.Request.Query = base.Request.Query
+	full.Request.PathVariable = base.Request.PathVariable
 
-	// 如果请求模式是 JSON 且现有的 request.params.json 为空，生成一个空的 JSON 示例
-	if base.Request.Params.Mode == "json" && (full.Request.Params.JSON == "" || strings.TrimSpace(full.Request.Params.JSON) == "") {
+	// 认证类型变化时才更新认证信息，保留页面上已填写的令牌，不需要认证的接口会清除页面的认证设置；cookie 保留已填写的值
+	if base.Request.Auth != nil && base.Request.Auth.Type != full.Request.Auth.Type {
+		full.Request.Auth = *base.Request.Auth
+	}
+	full.Request.Cookies = mergeCookies(full.Request.Cookies, base.Request.Cookies)
+
+	// 有请求体结构时使用结构生成的示例，否则在现有的 request.params.json 为空时生成一个简单的 JSON 示例
+	if base.Request.Params.JSON != "" {
+		full.Request.Params.JSON = base.Request.Params.JSON
+	} else if base.Request.Params.Mode == "json" && (full.Request.Params.JSON == "" || strings.TrimSpace(full.Request.Params.JSON) == "") {
 		// 根据参数生成 JSON 示例
 		jsonExample := generateJSONExample(base.Request.Params.JSONDesc)
 		full.Request.Params.JSON = jsonExample
@@ -148,11 +335,38 @@ func MergeWithFullContent(base PageContent, full PageContentFull) PageContentFul
 
 	// 更新响应结构
 	full.Response.ResponseParamsDesc = base.Response.ResponseParamsDesc
+	if base.Response.ResponseExample != "" {
+		full.Response.ResponseExample = base.Response.ResponseExample
+	}
+	// 没有在代码中声明失败响应时保留页面上已有的失败响应内容
+	if base.Response.ResponseFailExample != "" {
+		full.Response.ResponseFailExample = base.Response.ResponseFailExample
+	}
+	if len(base.Response.ResponseFailParamsDesc) > 0 {
+		full.Response.ResponseFailParamsDesc = base.Response.ResponseFailParamsDesc
+	}
 	full.Response.Remark = base.Response.Remark
 
 	return full
 }
 
+// mergeCookies 将认证需要的 cookie 添加到页面已有的 cookie 中，已存在的 cookie 保留原值
+func mergeCookies(existing, cookies []Cookie) []Cookie {
+	for _, cookie := range cookies {
+		found := false
+		for _, e := range existing {
+			if e.Name == cookie.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, cookie)
+		}
+	}
+	return existing
+}
+
 // generateJSONExample 根据参数描述生成 JSON 示例
 func generateJSONExample(params []Param) string {
 	if len(params) == 0 {
@@ -212,7 +426,7 @@ func CreateDefaultFullContent() PageContentFull {
 				Type:     "none",
 				Disabled: "0",
 			},
-			PathVariable: []json.RawMessage{},
+			PathVariable: []Param{},
 		},
 		Response: ResponseFull{
 			ResponseStatus: 200,
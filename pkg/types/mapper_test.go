@@ -0,0 +1,356 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertPathVariables(t *testing.T) {
+	content := APIDocToPageContent(APIDoc{
+		Title:  "获取用户",
+		Method: "get",
+		URL:    "/api/user/{id}",
+		Path:   []RequestParam{{Name: "id", Type: "int", Require: "true", Remark: "用户ID"}},
+	})
+
+	want := []Param{{Name: "id", Type: "int", Require: "1", Remark: "用户ID"}}
+	if len(content.Request.PathVariable) != len(want) || content.Request.PathVariable[0] != want[0] {
+		t.Errorf("pathVariable = %+v, want %+v", content.Request.PathVariable, want)
+	}
+
+	full := MergeWithFullContent(content, CreateDefaultFullContent())
+	if len(full.Request.PathVariable) != 1 || full.Request.PathVariable[0].Name != "id" {
+		t.Errorf("merged pathVariable = %+v", full.Request.PathVariable)
+	}
+}
+
+func TestRemarkWithConstraints(t *testing.T) {
+	one, hundred := 1.0, 100.0
+	tests := []struct {
+		remark      string
+		constraints Constraints
+		want        string
+	}{
+		{"页码", Constraints{}, "页码"},
+		{"页码", Constraints{Minimum: &one, Maximum: &hundred}, "页码（取值 1~100）"},
+		{"", Constraints{Enum: []string{"asc", "desc"}}, "可选值 asc/desc"},
+	}
+	for _, tt := range tests {
+		if got := remarkWithConstraints(tt.remark, tt.constraints); got != tt.want {
+			t.Errorf("remarkWithConstraints(%q) = %q, want %q", tt.remark, got, tt.want)
+		}
+	}
+
+	content := APIDocToPageContent(APIDoc{
+		Title:  "搜索",
+		Method: "get",
+		URL:    "/search",
+		Query:  []RequestParam{{Name: "page", Type: "int", Require: "true", Remark: "页码", Constraints: Constraints{Minimum: &one}}},
+	})
+	if remark := content.Request.Query[0].Remark; remark != "页码（取值 ≥1）" {
+		t.Errorf("query remark = %q", remark)
+	}
+}
+
+func TestConvertMultilineRemark(t *testing.T) {
+	remark := "## 错误码\n\n| 错误码 | 说明 |\n|--------|------|\n| 1001   | 密码错误 |"
+	content := APIDocToPageContent(APIDoc{
+		Title:       "用户登录",
+		Description: "用户登录的接口，\n支持账号密码和短信验证码两种方式",
+		Method:      "post",
+		URL:         "/login",
+		Remark:      remark,
+	})
+
+	full := MergeWithFullContent(content, CreateDefaultFullContent())
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"info.description", full.Info.Description, "用户登录的接口，\n支持账号密码和短信验证码两种方式"},
+		{"info.remark", full.Info.Remark, remark},
+		{"response.remark", full.Response.Remark, remark},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestConvertDeprecated(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    APIDoc
+		status string
+		remark string
+	}{
+		{"active", APIDoc{Remark: "备注"}, APIStatusNone, "备注"},
+		{"deprecated", APIDoc{Deprecated: true, DeprecatedNote: "请使用 /v2/users", Remark: "备注"}, APIStatusDeprecated, "> 已废弃：请使用 /v2/users\n\n备注"},
+		{"since without remark", APIDoc{Since: "v1.2"}, APIStatusNone, "> 起始版本：v1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doc.Title, tt.doc.Method, tt.doc.URL = "用户列表", "get", "/users"
+			content := APIDocToPageContent(tt.doc)
+			if content.Info.APIStatus != tt.status || content.Info.Remark != tt.remark || content.Response.Remark != tt.remark {
+				t.Errorf("info = {status: %s, remark: %q}, want {%s %q}", content.Info.APIStatus, content.Info.Remark, tt.status, tt.remark)
+			}
+			// 页面按标题匹配，不修改标题
+			if content.Info.Title != "用户列表" {
+				t.Errorf("title = %s", content.Info.Title)
+			}
+		})
+	}
+
+	// 取消废弃时恢复为未设置，保留页面上手动设置的其他状态
+	merges := []struct {
+		name   string
+		doc    APIDoc
+		page   string
+		status string
+	}{
+		{"deprecate", APIDoc{Deprecated: true}, APIStatusNone, APIStatusDeprecated},
+		{"undeprecate", APIDoc{}, APIStatusDeprecated, APIStatusNone},
+		{"keep manual status", APIDoc{}, "2", "2"},
+	}
+	for _, tt := range merges {
+		t.Run(tt.name, func(t *testing.T) {
+			full := CreateDefaultFullContent()
+			full.Info.APIStatus = tt.page
+			if merged := MergeWithFullContent(APIDocToPageContent(tt.doc), full); merged.Info.APIStatus != tt.status {
+				t.Errorf("apiStatus = %s, want %s", merged.Info.APIStatus, tt.status)
+			}
+		})
+	}
+}
+
+func TestConvertSecurity(t *testing.T) {
+	tests := []struct {
+		name     string
+		schemes  []SecurityScheme
+		authType string
+		headers  []string
+		query    []string
+		cookies  []string
+	}{
+		{"none", nil, "none", nil, nil, nil},
+		{"bearer", []SecurityScheme{{Name: "bearer", Type: "bearer"}}, "bearer", nil, nil, nil},
+		{"basic", []SecurityScheme{{Name: "basic", Type: "basic"}}, "basic", nil, nil, nil},
+		{"apiKey header", []SecurityScheme{{Name: "apikey", Type: "apiKey", In: "header", ParamName: "X-API-Key"}}, "none", []string{"X-Request-Id", "X-API-Key"}, nil, nil},
+		{"apiKey query", []SecurityScheme{{Name: "token", Type: "apiKey", In: "query", ParamName: "token"}}, "none", []string{"X-Request-Id"}, []string{"token"}, nil},
+		{"cookie", []SecurityScheme{{Name: "session", Type: "cookie", ParamName: "SESSIONID"}}, "none", nil, nil, []string{"SESSIONID"}},
+		{"first scheme only", []SecurityScheme{{Name: "bearer", Type: "bearer"}, {Name: "session", Type: "cookie", ParamName: "SESSIONID"}}, "bearer", nil, nil, nil},
+		// 已声明的同名请求头不重复添加
+		{"declared header", []SecurityScheme{{Name: "apikey", Type: "apiKey", In: "header", ParamName: "x-request-id"}}, "none", []string{"X-Request-Id"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := APIDoc{Title: "导出", Method: "get", URL: "/export", Security: tt.schemes}
+			if tt.headers != nil {
+				doc.Header = []RequestParam{{Name: "X-Request-Id", Type: "string", Require: "false"}}
+			}
+			content := APIDocToPageContent(doc)
+			if content.Request.Auth == nil || content.Request.Auth.Type != tt.authType {
+				t.Errorf("auth = %+v, want %s", content.Request.Auth, tt.authType)
+			}
+			var headers, query, cookies []string
+			for _, param := range content.Request.Headers {
+				headers = append(headers, param.Name)
+			}
+			for _, param := range content.Request.Query {
+				query = append(query, param.Name)
+			}
+			for _, cookie := range content.Request.Cookies {
+				cookies = append(cookies, cookie.Name)
+			}
+			if strings.Join(headers, ",") != strings.Join(tt.headers, ",") || strings.Join(query, ",") != strings.Join(tt.query, ",") || strings.Join(cookies, ",") != strings.Join(tt.cookies, ",") {
+				t.Errorf("headers = %v, query = %v, cookies = %v", headers, query, cookies)
+			}
+		})
+	}
+}
+
+func TestDescribeSecurity(t *testing.T) {
+	doc := APIDoc{
+		Description: "导出数据",
+		Security: []SecurityScheme{
+			{Name: "bearer", Type: "bearer"},
+			{Name: "session", Type: "cookie", ParamName: "SESSIONID"},
+			{Name: "apikey", Type: "apiKey", In: "header", ParamName: "X-API-Key", Description: "开放平台密钥"},
+		},
+	}
+	want := "导出数据\n\n也可使用以下认证方式：session（cookie SESSIONID）、apikey（apiKey header X-API-Key） 开放平台密钥"
+	if got := APIDocToPageContent(doc).Info.Description; got != want {
+		t.Errorf("description = %q, want %q", got, want)
+	}
+
+	doc.Security = doc.Security[:1]
+	if got := APIDocToPageContent(doc).Info.Description; got != "导出数据" {
+		t.Errorf("description = %q, want %q", got, "导出数据")
+	}
+}
+
+func TestMergeAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    Auth
+		schemes []SecurityScheme
+		want    Auth
+	}{
+		{
+			name:    "keep token when type unchanged",
+			page:    Auth{Type: "bearer", Disabled: "0", Bearer: &AuthBearer{Token: "abc"}},
+			schemes: []SecurityScheme{{Name: "bearer", Type: "bearer"}},
+			want:    Auth{Type: "bearer", Disabled: "0", Bearer: &AuthBearer{Token: "abc"}},
+		},
+		{
+			name:    "switch type",
+			page:    Auth{Type: "none", Disabled: "0"},
+			schemes: []SecurityScheme{{Name: "basic", Type: "basic"}},
+			want:    Auth{Type: "basic", Disabled: "0", Basic: &AuthBasic{}},
+		},
+		{
+			// @security none 清除页面的认证设置
+			name: "opt out",
+			page: Auth{Type: "bearer", Disabled: "0", Bearer: &AuthBearer{Token: "abc"}},
+			want: Auth{Type: "none", Disabled: "0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			full := CreateDefaultFullContent()
+			full.Request.Auth = tt.page
+			merged := MergeWithFullContent(APIDocToPageContent(APIDoc{Title: "导出", Method: "get", URL: "/export", Security: tt.schemes}), full)
+			got := merged.Request.Auth
+			if got.Type != tt.want.Type || (got.Bearer == nil) != (tt.want.Bearer == nil) || (got.Basic == nil) != (tt.want.Basic == nil) {
+				t.Fatalf("auth = %+v, want %+v", got, tt.want)
+			}
+			if got.Bearer != nil && got.Bearer.Token != tt.want.Bearer.Token {
+				t.Errorf("bearer token = %q, want %q", got.Bearer.Token, tt.want.Bearer.Token)
+			}
+		})
+	}
+
+	// cookie 保留页面上已填写的值
+	full := CreateDefaultFullContent()
+	full.Request.Cookies = []Cookie{{Name: "SESSIONID", Value: "s1"}}
+	merged := MergeWithFullContent(APIDocToPageContent(APIDoc{Security: []SecurityScheme{{Type: "cookie", ParamName: "SESSIONID"}}}), full)
+	if len(merged.Request.Cookies) != 1 || merged.Request.Cookies[0].Value != "s1" {
+		t.Errorf("cookies = %+v", merged.Request.Cookies)
+	}
+}
+
+func TestExamples(t *testing.T) {
+	order := &Schema{
+		Type: "object",
+		Children: []*Schema{
+			{Name: "id", Type: "long", Example: "1001"},
+			{Name: "status", Type: "string", Constraints: Constraints{Enum: []string{"paid", "shipped"}}},
+			{Name: "labels", Type: "array", Example: "a,b", Items: &Schema{Type: "string"}},
+			{Name: "meta", Type: "any", Example: `{"k":1}`},
+			{Name: "createdAt", Type: "string", Constraints: Constraints{Format: "date-time"}},
+			{Name: "items", Type: "array", Items: &Schema{Type: "object", Children: []*Schema{{Name: "price", Type: "double"}}}},
+			{Name: "children", Type: "array", Items: &Schema{Type: "object", Ref: "Order", Circular: true}},
+			{Name: "extra", Type: "object", AdditionalProperties: &Schema{Type: "int"}},
+		},
+	}
+	want := `{
+  "id": 1001,
+  "status": "paid",
+  "labels": [
+    "a",
+    "b"
+  ],
+  "meta": {
+    "k": 1
+  },
+  "createdAt": "2006-01-02T15:04:05Z",
+  "items": [
+    {
+      "price": 0
+    }
+  ],
+  "children": [],
+  "extra": {
+    "key": 0
+  }
+}`
+
+	content := APIDocToPageContent(APIDoc{
+		Title:          "创建订单",
+		Method:         "post",
+		Body:           []RequestParam{{Name: "id", Type: "long", Require: "true"}},
+		BodySchema:     order,
+		ResponseSchema: order,
+	})
+	if content.Request.Params.Mode != "json" || content.Request.Params.JSON != want {
+		t.Errorf("request json = %s, want %s", content.Request.Params.JSON, want)
+	}
+	if content.Response.ResponseExample != want {
+		t.Errorf("response example = %s, want %s", content.Response.ResponseExample, want)
+	}
+
+	// 已有页面的示例被结构生成的示例替换
+	full := CreateDefaultFullContent()
+	full.Request.Params.JSON = `{"old": true}`
+	full.Response.ResponseExample = `{"old": true}`
+	full = MergeWithFullContent(content, full)
+	if full.Request.Params.JSON != want || full.Response.ResponseExample != want {
+		t.Errorf("merged examples = %s / %s", full.Request.Params.JSON, full.Response.ResponseExample)
+	}
+}
+
+func TestConvertFailures(t *testing.T) {
+	errorSchema := &Schema{Type: "object", Children: []*Schema{{Name: "message", Type: "string", Example: "参数错误"}}}
+	tests := []struct {
+		name     string
+		failures []Failure
+		example  string
+		params   []string
+	}{
+		{
+			name:     "single failure",
+			failures: []Failure{{Status: 400, Schema: errorSchema, Body: []ResponseParam{{Name: "message", Type: "string"}}}},
+			example:  "{\n  \"message\": \"参数错误\"\n}",
+			params:   []string{"message"},
+		},
+		{
+			name: "multiple failures",
+			failures: []Failure{
+				{Status: 400, Description: "参数错误", Schema: errorSchema, Body: []ResponseParam{{Name: "message", Type: "string"}}},
+				{Status: 404, Description: "未找到"},
+				{Description: "其他错误", Schema: errorSchema, Body: []ResponseParam{{Name: "message", Type: "string"}, {Name: "code", Type: "int"}}},
+			},
+			example: "// 400 参数错误\n{\n  \"message\": \"参数错误\"\n}\n\n// 404 未找到\n\n// 失败 其他错误\n{\n  \"message\": \"参数错误\"\n}",
+			params:  []string{"message", "code"},
+		},
+		{
+			name: "no failures",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			example, params := convertFailures(tt.failures)
+			if example != tt.example {
+				t.Errorf("example = %q, want %q", example, tt.example)
+			}
+			var names []string
+			for _, param := range params {
+				names = append(names, param.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.params, ",") {
+				t.Errorf("params = %v, want %v", names, tt.params)
+			}
+		})
+	}
+
+	// 没有声明失败响应时保留页面上已有的内容
+	full := CreateDefaultFullContent()
+	full.Response.ResponseFailExample = `{"code": 500}`
+	full = MergeWithFullContent(APIDocToPageContent(APIDoc{Title: "获取"}), full)
+	if full.Response.ResponseFailExample != `{"code": 500}` {
+		t.Errorf("fail example = %s", full.Response.ResponseFailExample)
+	}
+}
@@ -0,0 +1,71 @@
+package types
+
+import "regexp"
+
+// Schema 表示树形的字段结构，扁平的参数列表是它的一种展示形式
+type Schema struct {
+	Name                 string    `json:"name,omitempty"`                  // 字段名，根节点为空
+	Type                 string    `json:"type"`                            // 文档类型：object、array、string、int、long、number、boolean、any
+	GoType               string    `json:"go_type,omitempty"`               // 原始Go类型
+	Required             bool      `json:"required,omitempty"`              // 是否必传
+	Nullable             bool      `json:"nullable,omitempty"`              // 是否可能为null（指针、interface）
+	Remark               string    `json:"remark,omitempty"`                // 字段注释
+	Example              string    `json:"example,omitempty"`               // 示例值（example标签）
+	Ref                  string    `json:"ref,omitempty"`                   // 来源结构体key
+	Circular             bool      `json:"circular,omitempty"`              // 循环引用，子字段见上层同一 Ref 的节点
+	Truncated            bool      `json:"truncated,omitempty"`             // 超过最大展开深度，子字段不再展开
+	Deprecated           bool      `json:"deprecated,omitempty"`            // 是否已废弃
+	DeprecatedNote       string    `json:"deprecated_note,omitempty"`       // 废弃说明
+	Children             []*Schema `json:"children,omitempty"`              // 对象的子字段
+	Items                *Schema   `json:"items,omitempty"`                 // 数组的元素类型
+	AdditionalProperties *Schema   `json:"additional_properties,omitempty"` // map的值类型
+	Constraints                    // 校验约束
+}
+
+// Field 按名称查找子字段
+func (s *Schema) Field(name string) *Schema {
+	for _, child := range s.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// Walk 按顺序遍历所有后代字段，path 为点号分隔的字段路径
+// 数组元素的子字段直接挂在数组字段路径下，如 list.id
+// map值的子字段使用 * 表示任意key，如 users.*.id
+func (s *Schema) Walk(fn func(path string, node *Schema)) {
+	s.walk("", fn)
+}
+
+// walk 递归遍历字段
+func (s *Schema) walk(prefix string, fn func(path string, node *Schema)) {
+	for _, child := range s.Children {
+		path := prefix + child.Name
+		fn(path, child)
+
+		// 数组元素可能是多维数组或map，找到最内层元素
+		element := child
+		for {
+			if element.Items != nil {
+				element = element.Items
+			} else if element.AdditionalProperties != nil {
+				element = element.AdditionalProperties
+				path += ".*"
+			} else {
+				break
+			}
+		}
+		element.walk(path+".", fn)
+	}
+}
+
+// packagePathPattern 匹配类型名前的包路径，如 example/model/
+var packagePathPattern = regexp.MustCompile(`[^\[\],\s]*/`)
+
+// ShortTypeName 去除结构体key中的包路径，只保留包名和类型名
+// 如 example/model/user.User 返回 user.User，response.Page[example/model/user.User] 返回 response.Page[user.User]
+func ShortTypeName(structKey string) string {
+	return packagePathPattern.ReplaceAllString(structKey, "")
+}
@@ -16,13 +16,24 @@ type Info struct {
 	Description string `json:"description"`
 	Method      string `json:"method"`
 	URL         string `json:"url"`
+	Remark      string `json:"remark,omitempty"`    // 接口备注，支持Markdown
+	APIStatus   string `json:"apiStatus,omitempty"` // 接口状态，见 APIStatusDeprecated
 }
 
+// ShowDoc 的接口状态
+const (
+	APIStatusNone       = "0" // 未设置
+	APIStatusDeprecated = "5" // 已废弃
+)
+
 // Request 请求信息
 type Request struct {
-	Params  Params  `json:"params"`
-	Headers []Param `json:"headers"`
-	Query   []Param `json:"query"`
+	Params       Params   `json:"params"`
+	Headers      []Param  `json:"headers"`
+	Query        []Param  `json:"query"`
+	PathVariable []Param  `json:"pathVariable"`
+	Cookies      []Cookie `json:"cookies,omitempty"` // cookie 认证的会话标识
+	Auth         *Auth    `json:"auth,omitempty"`    // 认证信息，接口没有认证方式时类型为 none
 }
 
 // Params 请求参数
@@ -31,6 +42,7 @@ type Params struct {
 	URLEncoded []Param `json:"urlencoded"`
 	FormData   []Param `json:"formdata"`
 	JSONDesc   []Param `json:"jsonDesc"`
+	JSON       string  `json:"json,omitempty"` // 根据请求体结构生成的JSON示例
 }
 
 // Param 参数结构
@@ -45,8 +57,11 @@ type Param struct {
 
 // Response 响应信息
 type Response struct {
-	ResponseParamsDesc []ResponseParamDesc `json:"responseParamsDesc"`
-	Remark             string              `json:"remark"`
+	ResponseParamsDesc     []ResponseParamDesc `json:"responseParamsDesc"`
+	ResponseExample        string              `json:"responseExample,omitempty"`        // 根据响应体结构生成的JSON示例
+	ResponseFailExample    string              `json:"responseFailExample,omitempty"`    // 根据失败响应结构生成的JSON示例
+	ResponseFailParamsDesc []ResponseParamDesc `json:"responseFailParamsDesc,omitempty"` // 失败响应参数说明
+	Remark                 string              `json:"remark"`
 }
 
 // ResponseParamDesc 响应参数描述
@@ -81,12 +96,12 @@ type InfoFull struct {
 
 // RequestFull 完整请求信息
 type RequestFull struct {
-	Params       ParamsFull        `json:"params"`
-	Headers      []Param           `json:"headers"`
-	Cookies      []Cookie          `json:"cookies"`
-	Auth         Auth              `json:"auth"`
-	Query        []Param           `json:"query"`
-	PathVariable []json.RawMessage `json:"pathVariable"`
+	Params       ParamsFull `json:"params"`
+	Headers      []Param    `json:"headers"`
+	Cookies      []Cookie   `json:"cookies"`
+	Auth         Auth       `json:"auth"`
+	Query        []Param    `json:"query"`
+	PathVariable []Param    `json:"pathVariable"`
 }
 
 // ParamsFull 完整参数
@@ -106,8 +121,21 @@ type Cookie struct {
 
 // Auth 认证信息
 type Auth struct {
-	Type     string `json:"type"`
-	Disabled string `json:"disabled"`
+	Type     string      `json:"type"` // none、bearer、basic
+	Disabled string      `json:"disabled"`
+	Bearer   *AuthBearer `json:"bearer,omitempty"`
+	Basic    *AuthBasic  `json:"basic,omitempty"`
+}
+
+// AuthBearer Bearer 认证的令牌，由使用者在页面上填写
+type AuthBearer struct {
+	Token string `json:"token"`
+}
+
+// AuthBasic Basic 认证的用户名和密码，由使用者在页面上填写
+type AuthBasic struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
 }
 
 // ResponseFull 完整响应信息
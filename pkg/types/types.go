@@ -1,53 +1,157 @@
 package types
 
+import "strings"
+
 // RequestParam 表示请求参数的结构
 type RequestParam struct {
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Require string `json:"require"`
-	Remark  string `json:"remark"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Require    string `json:"require"`
+	Remark     string `json:"remark"`
+	Deprecated bool   `json:"deprecated,omitempty"` // 是否已废弃
+	Constraints
 }
 
 // ResponseParam 表示响应参数的结构
 type ResponseParam struct {
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Required bool   `json:"required"` // 是否必传（基于omitempty标签）
-	Remark   string `json:"remark"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Required   bool   `json:"required"` // 是否必传（基于omitempty标签）
+	Remark     string `json:"remark"`
+	Deprecated bool   `json:"deprecated,omitempty"` // 是否已废弃
 }
 
 // APIDoc 表示一个API文档的结构
 type APIDoc struct {
-	Title          string          `json:"title"`
-	Catalog        string          `json:"catalog"`
-	Description    string          `json:"description"`
-	Method         string          `json:"method"`
-	Router         string          `json:"router,omitempty"`
-	URL            string          `json:"url,omitempty"`
-	Header         []RequestParam  `json:"header,omitempty"`
-	Query          []RequestParam  `json:"query,omitempty"`
-	FormData       []RequestParam  `json:"formData,omitempty"`
-	Body           []RequestParam  `json:"body,omitempty"`
-	ResponseHeader []ResponseParam `json:"response_header,omitempty"`
-	ResponseBody   []ResponseParam `json:"response_body,omitempty"`
-	Remark         string          `json:"remark,omitempty"`
+	Title          string           `json:"title"`
+	Catalog        string           `json:"catalog"`
+	Description    string           `json:"description"`
+	Method         string           `json:"method"`
+	Router         string           `json:"router,omitempty"`
+	URL            string           `json:"url,omitempty"`
+	Path           []RequestParam   `json:"path,omitempty"`
+	Header         []RequestParam   `json:"header,omitempty"`
+	Query          []RequestParam   `json:"query,omitempty"`
+	FormData       []RequestParam   `json:"formData,omitempty"`
+	Body           []RequestParam   `json:"body,omitempty"`
+	ResponseHeader []ResponseParam  `json:"response_header,omitempty"`
+	ResponseBody   []ResponseParam  `json:"response_body,omitempty"`
+	Remark         string           `json:"remark,omitempty"`
+	BodySchema     *Schema          `json:"body_schema,omitempty"`     // 请求体树形结构
+	ResponseSchema *Schema          `json:"response_schema,omitempty"` // 响应体树形结构
+	Failures       []Failure        `json:"failures,omitempty"`        // 失败响应，同一状态码可以有多个
+	Deprecated     bool             `json:"deprecated,omitempty"`      // 是否已废弃
+	DeprecatedNote string           `json:"deprecated_note,omitempty"` // 废弃说明，如替代的接口
+	Since          string           `json:"since,omitempty"`           // 起始版本，如 v1.2
+	Sunset         string           `json:"sunset,omitempty"`          // 计划下线日期，格式为 2006-01-02
+	Security       []SecurityScheme `json:"security,omitempty"`        // 认证方式，满足其中任一即可
 	// 内部使用，不序列化到JSON
 	FilePath     string `json:"-"`
 	FunctionName string `json:"-"`
+	Line         int    `json:"-"` // 文档注释所在的行号，与 FilePath 一起用于诊断信息定位
+}
+
+// SunsetReached 是否已到达计划下线日期，today 的格式为 2006-01-02
+func (d APIDoc) SunsetReached(today string) bool {
+	return d.Sunset != "" && d.Sunset <= today
+}
+
+// LifecycleNotice 返回接口生命周期的说明，如 "已废弃：请使用 /v2/users；起始版本：v1.2；计划下线：2027-01-01"
+func (d APIDoc) LifecycleNotice() string {
+	var parts []string
+	if d.Deprecated {
+		parts = append(parts, DeprecatedRemark("", d.DeprecatedNote))
+	}
+	if d.Since != "" {
+		parts = append(parts, "起始版本："+d.Since)
+	}
+	if d.Sunset != "" {
+		parts = append(parts, "计划下线："+d.Sunset)
+	}
+	return strings.Join(parts, "；")
+}
+
+// DeprecatedRemark 在注释后追加废弃说明，如 "昵称（已废弃：使用 nickname）"，注释为空时只返回废弃说明
+func DeprecatedRemark(remark, note string) string {
+	notice := "已废弃"
+	if note != "" {
+		notice += "：" + note
+	}
+	if remark == "" {
+		return notice
+	}
+	return remark + "（" + notice + "）"
+}
+
+// SecurityScheme 表示接口的认证方式
+type SecurityScheme struct {
+	Name         string `json:"name"`                    // 认证方式名称
+	Type         string `json:"type"`                    // 类型：bearer、apiKey、basic、cookie
+	In           string `json:"in,omitempty"`            // apiKey 的位置：header、query
+	ParamName    string `json:"param_name,omitempty"`    // apiKey 的请求头或参数名，cookie 的名称
+	BearerFormat string `json:"bearer_format,omitempty"` // bearer 令牌的格式，如 JWT
+	Description  string `json:"description,omitempty"`   // 说明
+}
+
+// Failure 表示一个失败响应
+type Failure struct {
+	Status      int             `json:"status,omitempty"`      // HTTP状态码，0表示未指定状态码的默认失败响应
+	Description string          `json:"description,omitempty"` // 失败描述
+	Body        []ResponseParam `json:"body,omitempty"`        // 失败响应体参数
+	Schema      *Schema         `json:"schema,omitempty"`      // 失败响应体树形结构
 }
 
 // StructInfo 表示结构体信息
 type StructInfo struct {
 	Name        string
-	Package     string // 结构体所属的包名
-	PackagePath string // 包的完整路径
+	Package     string   // 结构体所属的包名
+	PackagePath string   // 包的完整路径
+	TypeParams  []string // 泛型结构体的类型参数，如 Page[T any] 为 [T]
+	FilePath    string   // 声明所在的文件，按其导入信息解析字段类型中的包名
 	Fields      []FieldInfo
 }
 
+// NamedType 表示底层类型为基本类型的命名类型，如 type Status int
+type NamedType struct {
+	Name       string
+	Package    string      // 所在包名，用于解析底层类型中的同包类型
+	FilePath   string      // 声明所在的文件，按其导入信息解析底层类型中的包名
+	Underlying string      // 底层类型，如 int、string、[]string、map[string]user.User
+	Values     []EnumValue // 同包中该类型的常量，按声明顺序
+}
+
+// EnumValue 表示命名类型的一个常量值
+type EnumValue struct {
+	Name   string // 常量名
+	Value  string // 常量值，字符串常量为去掉引号后的值
+	Remark string // 常量注释
+}
+
+// EnumConstraints 返回以常量值作为可选值的约束，没有常量时为空
+func (n NamedType) EnumConstraints() Constraints {
+	var c Constraints
+	for _, value := range n.Values {
+		c.Enum = append(c.Enum, value.Value)
+		c.EnumRemarks = append(c.EnumRemarks, value.Remark)
+	}
+	return c
+}
+
 // FieldInfo 表示结构体字段的完整信息
 type FieldInfo struct {
-	Name     string // 字段名
-	Type     string // Go类型
-	Required bool   // 是否必传（基于omitempty标签）
-	Remark   string // 字段注释
+	Name           string      // 字段名（JSON序列化后的名称）
+	GoName         string      // Go字段名，嵌入字段为类型名
+	Embedded       bool        // 是否为嵌入字段
+	Tag            string      // 原始结构体标签
+	Type           string      // Go类型
+	Required       bool        // 是否必传（基于omitempty标签，请求参数中binding/validate标签的required规则优先）
+	Remark         string      // 字段注释
+	Ref            string      // 字段类型（去除指针、切片和map后）对应的结构体key，非结构体为空
+	Fields         []FieldInfo // 匿名结构体字段的子字段
+	Example        string      // 示例值（example标签）
+	JSONString     bool        // json标签的 string 选项，数值和布尔值序列化为JSON字符串
+	JSONSkip       bool        // json标签为 -，不参与JSON序列化，仍可用于 uri/form/header 绑定
+	Deprecated     bool        // 是否已废弃（字段注释中的 Deprecated: 或 @deprecated）
+	DeprecatedNote string      // 废弃说明
+	Constraints                // 校验约束（binding/validate/enums标签）
 }
@@ -0,0 +1,56 @@
+package types
+
+import "testing"
+
+func TestLifecycleNotice(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  APIDoc
+		want string
+	}{
+		{"none", APIDoc{}, ""},
+		{"deprecated", APIDoc{Deprecated: true}, "已废弃"},
+		{"all", APIDoc{Deprecated: true, DeprecatedNote: "请使用 /v2/users", Since: "v1.2", Sunset: "2027-01-01"}, "已废弃：请使用 /v2/users；起始版本：v1.2；计划下线：2027-01-01"},
+		{"since only", APIDoc{Since: "v1.2"}, "起始版本：v1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.doc.LifecycleNotice(); got != tt.want {
+				t.Errorf("LifecycleNotice() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSunsetReached(t *testing.T) {
+	tests := []struct {
+		sunset string
+		today  string
+		want   bool
+	}{
+		{"", "2027-01-01", false},
+		{"2027-01-01", "2026-12-31", false},
+		{"2027-01-01", "2027-01-01", true},
+		{"2027-01-01", "2027-06-01", true},
+	}
+	for _, tt := range tests {
+		if got := (APIDoc{Sunset: tt.sunset}).SunsetReached(tt.today); got != tt.want {
+			t.Errorf("SunsetReached(%s) with sunset %q = %t, want %t", tt.today, tt.sunset, got, tt.want)
+		}
+	}
+}
+
+func TestDeprecatedRemark(t *testing.T) {
+	tests := []struct {
+		remark, note, want string
+	}{
+		{"昵称", "使用 nickname", "昵称（已废弃：使用 nickname）"},
+		{"昵称", "", "昵称（已废弃）"},
+		{"", "使用 nickname", "已废弃：使用 nickname"},
+	}
+	for _, tt := range tests {
+		if got := DeprecatedRemark(tt.remark, tt.note); got != tt.want {
+			t.Errorf("DeprecatedRemark(%q, %q) = %q, want %q", tt.remark, tt.note, got, tt.want)
+		}
+	}
+}